@@ -6,8 +6,15 @@ package konf
 import (
 	"context"
 	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
 	"slices"
 	"strings"
 	"sync"
@@ -30,13 +37,27 @@ type Config struct {
 	caseSensitive       bool
 	mapKeyCaseSensitive bool
 	delimiter           string
+	pathParser          func(string) []string
+	expandEnv           func(string) (string, error)
+	interpolation       bool
+	lazyResolution      bool
+	blurOnMarshal       bool
+	blurPatterns        credential.Patterns
+	restartBackoff      time.Duration
 	logger              *slog.Logger
 	onStatus            func(loader Loader, changed bool, err error)
+	metrics             MetricsRecorder
+	convertOpts         []convert.Option
 	converter           *convert.Converter
 
+	decoder   atomic.Pointer[decoder] // For Config.AddDecodeOptions.
 	providers providers
 	onChanges onChanges
 	watched   atomic.Pointer[func(*provider)]
+
+	// For the sub Config created by Config.Sub.
+	parent *Config
+	prefix []string
 }
 
 // New creates a new Config with the given Option(s).
@@ -50,6 +71,28 @@ func New(opts ...Option) *Config {
 	if len(option.convertOpts) == 0 {
 		option.convertOpts = defaultHooks
 	}
+	if option.durationUnit > 0 {
+		// It takes precedence over the hook converting string to time.Duration.
+		option.convertOpts = append(
+			[]convert.Option{convert.WithHook[any, time.Duration](durationHook(option.durationUnit))},
+			option.convertOpts...,
+		)
+	}
+	if option.bytesDecoder != nil {
+		// It takes precedence over copying the raw bytes of string to []byte.
+		option.convertOpts = append(
+			[]convert.Option{convert.WithHook[string, []byte](option.bytesDecoder)},
+			option.convertOpts...,
+		)
+	}
+	if len(option.timeLayouts) > 0 {
+		// It takes precedence over the default hook converting string to time.Time.
+		option.convertOpts = append(
+			[]convert.Option{convert.WithHook[string, time.Time](timeHook(option.timeLayouts))},
+			option.convertOpts...,
+		)
+	}
+	option.convertOpts = append(registeredHooks(), option.convertOpts...)
 	if option.tagName == "" {
 		option.tagName = defaultTagName
 	}
@@ -57,7 +100,22 @@ func New(opts ...Option) *Config {
 	if !option.caseSensitive {
 		option.convertOpts = append(option.convertOpts, convert.WithKeyMapper(defaultKeyMap))
 	}
+	if option.errorUnused {
+		option.convertOpts = append(option.convertOpts, convert.WithErrorUnused())
+	}
+	if option.intBase != 0 {
+		option.convertOpts = append(option.convertOpts, convert.WithIntBase(option.intBase))
+	}
+	if option.weaklyTyped {
+		option.convertOpts = append(option.convertOpts, convert.WithWeaklyTypedInput())
+	}
 	option.converter = convert.New(option.convertOpts...)
+	if option.duplicateKeyHandler != nil {
+		delimiter := option.delim()
+		option.providers.onDuplicate = func(path []string, loaders []string) {
+			option.duplicateKeyHandler(strings.Join(path, delimiter), loaders)
+		}
+	}
 
 	return &(option.Config)
 }
@@ -71,11 +129,115 @@ func (c *Config) Load(loader Loader) error {
 		return nil
 	}
 	c.nocopy.Check()
+	c.checkInit()
+	if c.parent != nil {
+		return c.parent.Load(loader)
+	}
+
+	provider, err := c.load(loader)
+	if err != nil {
+		return err
+	}
+	c.providers.append(provider)
+	c.watch(provider)
+
+	return nil
+}
+
+// LoadAll loads configuration from the given loaders in order,
+// and merges the configuration once after all loaders are loaded.
+// Each loader takes precedence over the loaders before it.
+//
+// If any loader fails, the configuration from the successful loaders is still applied,
+// and the errors of all failed loaders are returned together.
+// Since the configuration is merged at the end, the loaders could not
+// check the configuration of the loaders before it via Config.Exists.
+//
+// This method is concurrent-safe.
+func (c *Config) LoadAll(loaders ...Loader) error {
+	c.nocopy.Check()
+	c.checkInit()
+	if c.parent != nil {
+		return c.parent.LoadAll(loaders...)
+	}
 
+	var (
+		providers = make([]*provider, 0, len(loaders))
+		errs      []error
+	)
+	for _, loader := range loaders {
+		if loader == nil {
+			continue
+		}
+		provider, err := c.load(loader)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	c.providers.append(providers...)
+	for _, provider := range providers {
+		c.watch(provider)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Unload removes the given loader from the Config, and returns whether it has been loaded.
+// The loader is matched by identity, e.g. the same pointer passed to Config.Load,
+// or the same fields for the struct loaders, e.g. env.New() or konf.StripPrefix(...).
+// The onChange callbacks registered on the paths whose values change are executed after unloading.
+//
+// The watched loader should be stopped by cancelling the context of Config.Watch before unloading,
+// otherwise it still receives changes, which are not applied to the Config.
+//
+// This method is concurrent-safe.
+func (c *Config) Unload(loader Loader) bool {
+	if c == nil || loader == nil {
+		return false
+	}
+	c.nocopy.Check()
+	if c.parent != nil {
+		return c.parent.Unload(loader)
+	}
+
+	oldValues, _ := c.providers.peek(nil).(map[string]any)
+	if !c.providers.remove(loader) {
+		return false
+	}
+	c.log(context.Background(), slog.LevelInfo, "Configuration has been unloaded.", slog.Any("loader", loader))
+
+	newValues, _ := c.providers.peek(nil).(map[string]any)
+	onChanges := c.onChanges.get(
+		func(path string) bool {
+			paths := c.splitPath(path)
+
+			return !reflect.DeepEqual(maps.Sub(oldValues, paths), maps.Sub(newValues, paths))
+		},
+	)
+	if batches := c.onChanges.getBatches(); len(batches) > 0 {
+		added, removed, changed := Diff(oldValues, newValues, c.delim())
+		if paths := slices.Concat(added, removed, changed); len(paths) > 0 {
+			for _, batch := range batches {
+				batch.add(c, paths)
+			}
+		}
+	}
+	for _, onChange := range onChanges {
+		onChange(c)
+	}
+
+	return true
+}
+
+func (c *Config) load(loader Loader) (*provider, error) {
 	// Register status callback if the loader is a Statuser.
 	if statuser, ok := loader.(Statuser); ok {
 		statuser.Status(func(changed bool, err error) {
 			if err != nil {
+				c.recorder().LoadError(fmt.Sprintf("%v", loader))
 				c.log(context.Background(),
 					slog.LevelWarn,
 					"Error when loading configuration.",
@@ -89,50 +251,295 @@ func (c *Config) Load(loader Loader) error {
 		})
 	}
 
-	// Load values into a new provider.
-	values, err := loader.Load()
+	provider := &provider{loader: loader}
+	if _, ok := loader.(*lazyLoader); ok {
+		// Defer loading until the configuration is read for the first time.
+		values := make(map[string]any)
+		provider.values.Store(&values)
+		provider.resolve = sync.OnceFunc(func() {
+			if err := c.loadProvider(provider); err != nil {
+				c.log(context.Background(),
+					slog.LevelWarn,
+					"Error when loading configuration lazily.",
+					slog.Any("loader", loader),
+					slog.Any("error", err),
+				)
+			}
+		})
+
+		return provider, nil
+	}
+
+	if err := c.loadProvider(provider); err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// loadProvider loads values from the loader into the provider.
+func (c *Config) loadProvider(provider *provider) error {
+	start := time.Now()
+	values, err := provider.loader.Load()
 	if err != nil {
+		c.recorder().LoadError(fmt.Sprintf("%v", provider.loader))
+
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	duration := time.Since(start)
+	c.recorder().LoadDuration(fmt.Sprintf("%v", provider.loader), duration)
+	if values, err = c.transform(values); err != nil {
 		return fmt.Errorf("load configuration: %w", err)
 	}
-	c.transformKeys(values)
-	provider := c.providers.append(loader, values)
+	provider.values.Store(&values)
+	provider.duration.Store(int64(duration))
+
+	return nil
+}
 
-	if _, ok := loader.(Watcher); ok {
+func (c *Config) watch(provider *provider) {
+	if _, ok := provider.loader.(Watcher); ok {
 		// Register watch callback if the loader is a Watcher and the watch is started.
 		// While Config.Watch is called, c.watched is set for registering the watch callback.
 		if watch := c.watched.Load(); watch != nil {
 			(*watch)(provider)
 		}
 	}
-
-	return nil
 }
 
 // Unmarshal reads configuration under the given path from the Config
 // and decodes it into the given object pointed to by target.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// The map with numeric keys (e.g. `{"0": "x", "1": "y"}`) decodes into a slice ordered by the keys,
+// and the gaps between keys are dropped, e.g. `{"0": "x", "2": "z"}` decodes into `["x", "z"]`.
+//
+// It returns an error with the field path if the value mismatches the target,
+// e.g. a scalar for a struct field, and leaves the pointer field nil rather than pointing to a zero value.
 func (c *Config) Unmarshal(path string, target any) error {
+	return c.UnmarshalContext(context.Background(), path, target)
+}
+
+// MustUnmarshal works like Config.Unmarshal, but panics if there is an error,
+// e.g. failing fast in the application startup.
+// The panic value is an error wrapping the error of Config.Unmarshal with the path.
+func (c *Config) MustUnmarshal(path string, target any) {
+	if err := c.Unmarshal(path, target); err != nil {
+		panic(fmt.Errorf("unmarshal %q: %w", path, err))
+	}
+}
+
+// UnmarshalContext works like Config.Unmarshal, but checks whether ctx is done
+// at each level of struct, map and slice while decoding, and returns ctx.Err() once it's done.
+// It bounds the decoding of large configuration, e.g. in a request handler.
+func (c *Config) UnmarshalContext(ctx context.Context, path string, target any) error {
 	if c == nil { // To support nil
 		return nil
 	}
 	c.nocopy.Check()
+	c.checkInit()
 
-	value := c.providers.sub(c.splitPath(path))
-	if value == nil {
+	_, converter := c.converters()
+
+	return c.unmarshal(ctx, path, target, converter)
+}
+
+// UnmarshalWith works like Config.Unmarshal, but decodes with the given DecodeOption(s)
+// on top of the options of the Config, e.g. konf.DecodeErrorUnused.
+// The DecodeOption(s) only apply to this call.
+func (c *Config) UnmarshalWith(path string, target any, opts ...DecodeOption) error {
+	if c == nil { // To support nil
 		return nil
 	}
+	c.nocopy.Check()
+	c.checkInit()
+
+	option := &decodeOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	// The hooks of DecodeOption(s) take precedence over the hooks of the Config.
+	convertOpts, _ := c.converters()
+	converter := convert.New(append(option.convertOpts, convertOpts...)...)
+
+	return c.unmarshal(context.Background(), path, target, converter)
+}
+
+// AddDecodeOptions adds the given DecodeOption(s) to the Config, e.g. konf.DecodeHook,
+// which apply to the subsequent calls of unmarshalling without reloading the configuration.
+// The added hooks take precedence over the hooks of the Config for the same types.
+// It's useful when the hooks depend on the types discovered at runtime, e.g. registered by plugins.
+// It also applies to the sub Config(s) created by Config.Sub.
+//
+// This method is concurrent-safe.
+func (c *Config) AddDecodeOptions(opts ...DecodeOption) {
+	if c == nil || len(opts) == 0 {
+		return
+	}
+	c.nocopy.Check()
+
+	option := &decodeOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+	root := c.root()
+	for {
+		old := root.decoder.Load()
+		convertOpts, _ := root.converters()
+		convertOpts = append(slices.Clone(option.convertOpts), convertOpts...)
+		if root.decoder.CompareAndSwap(old, &decoder{convertOpts: convertOpts, converter: convert.New(convertOpts...)}) {
+			return
+		}
+	}
+}
+
+type decoder struct {
+	convertOpts []convert.Option
+	converter   *convert.Converter
+}
 
-	converter := c.converter
-	if converter == nil { // To support zero Config
-		converter = defaultConverter
+// converters returns the convert options and the converter
+// with the DecodeOption(s) added by Config.AddDecodeOptions.
+func (c *Config) converters() ([]convert.Option, *convert.Converter) {
+	if decoder := c.root().decoder.Load(); decoder != nil {
+		return decoder.convertOpts, decoder.converter
 	}
-	if err := converter.Convert(value, target); err != nil {
+	if c.converter == nil { // To support zero Config
+		return zeroConvertOpts(), zeroConverter()
+	}
+
+	return c.convertOpts, c.converter
+}
+
+// GetFrom retrieves the value under the given path from the given Config.
+// It returns the zero value of the expected type if the path is missing or there is an error,
+// and logs the error as warning.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+func GetFrom[T any](config *Config, path string) T { //nolint:ireturn
+	var value T
+	if err := config.Unmarshal(path, &value); err != nil {
+		config.log(context.Background(),
+			slog.LevelWarn,
+			"Could not read config, return empty value instead.",
+			slog.String("path", path),
+			slog.Any("type", reflect.TypeOf(value)),
+			slog.Any("error", err),
+		)
+	}
+
+	return value
+}
+
+// GetOr retrieves the value under the given path from the given Config.
+// It returns the given fallback if the path is missing or there is an error,
+// and logs the error as warning.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+func GetOr[T any](config *Config, path string, fallback T) T { //nolint:ireturn
+	if config == nil || config.root().providers.sub(config.splitPath(path)) == nil {
+		return fallback
+	}
+
+	var value T
+	if err := config.Unmarshal(path, &value); err != nil {
+		config.log(context.Background(),
+			slog.LevelWarn,
+			"Could not read config, return fallback value instead.",
+			slog.String("path", path),
+			slog.Any("type", reflect.TypeOf(value)),
+			slog.Any("error", err),
+		)
+
+		return fallback
+	}
+
+	return value
+}
+
+func (c *Config) unmarshal(ctx context.Context, path string, target any, converter *convert.Converter) error {
+	var value any
+	if c.lazyResolution {
+		value = c.root().providers.first(c.splitPath(path))
+	} else {
+		value = c.root().providers.sub(c.splitPath(path))
+	}
+	// It still decodes if the value is missing, so the default values of the target are applied.
+	if value != nil && c.interpolation {
+		var err error
+		if value, err = c.interpolate(value); err != nil {
+			return fmt.Errorf("interpolate: %w", err)
+		}
+	}
+
+	if err := converter.ConvertContext(ctx, value, target); err != nil {
+		if ctx.Err() != nil {
+			return err //nolint:wrapcheck // It's ctx.Err().
+		}
+
 		return fmt.Errorf("decode: %w", err)
 	}
 
 	return nil
 }
 
+// Sub returns a sub Config rooted at the given path, which shares the loaders and options with the Config.
+// For example, `config.Sub("parent").Unmarshal("child", &target)` is the same as
+// `config.Unmarshal("parent.child", &target)`, and Config.OnChange on the sub Config
+// only executes the callback for the changes under the path.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// Config.Load and Config.Watch on the sub Config are delegated to the Config.
+func (c *Config) Sub(path string) *Config {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	return &Config{
+		caseSensitive:       c.caseSensitive,
+		mapKeyCaseSensitive: c.mapKeyCaseSensitive,
+		delimiter:           c.delimiter,
+		pathParser:          c.pathParser,
+		expandEnv:           c.expandEnv,
+		interpolation:       c.interpolation,
+		lazyResolution:      c.lazyResolution,
+		blurOnMarshal:       c.blurOnMarshal,
+		blurPatterns:        c.blurPatterns,
+		restartBackoff:      c.restartBackoff,
+		logger:              c.logger,
+		onStatus:            c.onStatus,
+		metrics:             c.metrics,
+		convertOpts:         c.convertOpts,
+		converter:           c.converter,
+		parent:              c.root(),
+		prefix:              slices.Clip(c.splitPath(path)),
+	}
+}
+
+// LoadTimings returns how long the latest load of each loader took, keyed by the loader's string representation.
+// For the changes from the watchers, it's how long the Config took to reload the changed values,
+// since the watchers fetch the configuration internally.
+//
+// This method is concurrent-safe.
+func (c *Config) LoadTimings() map[string]time.Duration {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	timings := make(map[string]time.Duration)
+	c.root().providers.traverse(func(provider *provider) {
+		timings[fmt.Sprintf("%v", provider.loader)] = time.Duration(provider.duration.Load())
+	})
+
+	return timings
+}
+
+func (c *Config) checkInit() {
+	if c.converter == nil && strictInit.Load() {
+		panic("illegal use of zero Config, create it with konf.New")
+	}
+}
+
 func (c *Config) log(ctx context.Context, level slog.Level, message string, attrs ...slog.Attr) {
 	logger := c.logger
 	if c.logger == nil { // To support zero Config
@@ -141,15 +548,38 @@ func (c *Config) log(ctx context.Context, level slog.Level, message string, attr
 	logger.LogAttrs(ctx, level, message, attrs...)
 }
 
+func (c *Config) recorder() MetricsRecorder {
+	if c.metrics == nil { // To support zero Config
+		return nopMetrics{}
+	}
+
+	return c.metrics
+}
+
+// splitPath returns a new slice of keys for the path with the prefix,
+// so the caller is free to modify it (e.g. maps.Sub compacts it in place).
 func (c *Config) splitPath(path string) []string {
 	if path == "" {
-		return nil
+		return slices.Clone(c.prefix)
 	}
 	if !c.caseSensitive {
 		path = defaultKeyMap(path)
 	}
 
-	return strings.Split(path, c.delim())
+	if c.pathParser != nil {
+		return slices.Concat(c.prefix, c.pathParser(path))
+	}
+
+	return slices.Concat(c.prefix, strings.Split(path, c.delim()))
+}
+
+// root returns the Config which owns the loaders, which is the parent for the sub Config.
+func (c *Config) root() *Config {
+	if c.parent != nil {
+		return c.parent
+	}
+
+	return c
 }
 
 func (c *Config) delim() string {
@@ -160,32 +590,116 @@ func (c *Config) delim() string {
 	return c.delimiter
 }
 
-func (c *Config) transformKeys(m map[string]any) {
+// transform transforms the values loaded by loaders, e.g. lower-casing the keys
+// and expanding the environment variables.
+func (c *Config) transform(values map[string]any) (map[string]any, error) {
 	if !c.caseSensitive {
-		maps.TransformKeys(m, defaultKeyMap, c.mapKeyCaseSensitive)
+		maps.TransformKeys(values, defaultKeyMap, c.mapKeyCaseSensitive)
 	}
+	// The environment variables are expanded while unmarshalling if the interpolation is enabled.
+	if c.expandEnv != nil && !c.interpolation && values != nil {
+		expanded, err := expandValues(values, nil, c.delim(), c.expandEnv)
+		if err != nil {
+			return nil, err
+		}
+		values, _ = expanded.(map[string]any)
+	}
+
+	return values, nil
 }
 
 // Explain provides information about how Config resolve each value
 // from loaders for the given path. It blur sensitive information.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
 func (c *Config) Explain(path string) string {
+	return c.ExplainWith(path)
+}
+
+// ExplainWith works like Config.Explain, but explains with the given ExplainOption(s),
+// e.g. konf.ExplainReveal for revealing sensitive information while debugging.
+func (c *Config) ExplainWith(path string, opts ...ExplainOption) string {
 	if c == nil { // To support nil
 		return path + " has no configuration.\n\n"
 	}
 	c.nocopy.Check()
 
-	value := c.providers.sub(c.splitPath(path))
+	option := &explainOptions{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	keys := c.splitPath(path)
+	value := c.root().providers.sub(keys)
 	if value == nil {
 		return path + " has no configuration.\n\n"
 	}
+	format := c.blurPatterns.Blur
+	if option.reveal {
+		format = func(_ string, value any) string { return credential.Format(value) }
+	}
 	explanation := &strings.Builder{}
-	c.explain(explanation, path, value)
+	c.explain(explanation, path, keys, value, format)
 
 	return explanation.String()
 }
 
-func (c *Config) explain(explanation *strings.Builder, path string, value any) {
+// ExplainOverlay provides information about how Config would resolve each value
+// for the given path if the given loader were loaded, which takes precedence over
+// all loaders that have been loaded. It blur sensitive information.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// It does not change the Config nor call the handler of konf.WithDuplicateKeyHandler,
+// and the given loader is not watched.
+func (c *Config) ExplainOverlay(loader Loader, path string) (string, error) {
+	if c == nil { // To support nil
+		c = &Config{}
+	}
+	c.nocopy.Check()
+
+	overlay := &Config{
+		caseSensitive:       c.caseSensitive,
+		mapKeyCaseSensitive: c.mapKeyCaseSensitive,
+		delimiter:           c.delimiter,
+		pathParser:          c.pathParser,
+		expandEnv:           c.expandEnv,
+		interpolation:       c.interpolation,
+		lazyResolution:      c.lazyResolution,
+		blurPatterns:        c.blurPatterns,
+		converter:           c.converter,
+		prefix:              c.prefix,
+		// Merge the overlay the same way as the Config,
+		// but without the duplicate key handler since it's a dry run without side effects.
+		providers: providers{merge: c.root().providers.merge},
+	}
+	c.root().providers.resolve()
+	c.root().providers.traverse(func(provider *provider) {
+		overlay.providers.providers = append(overlay.providers.providers, provider)
+	})
+	if loader == nil {
+		overlay.providers.changed()
+
+		return overlay.Explain(path), nil
+	}
+
+	values, err := loader.Load()
+	if err != nil {
+		return "", fmt.Errorf("load configuration: %w", err)
+	}
+	if values, err = overlay.transform(values); err != nil {
+		return "", fmt.Errorf("load configuration: %w", err)
+	}
+	provider := &provider{loader: loader}
+	provider.values.Store(&values)
+	overlay.providers.append(provider)
+
+	return overlay.Explain(path), nil
+}
+
+// explain writes the explanation of the value with the given path for display,
+// and keys for lookup since the path may not be split back by the custom path parser.
+func (c *Config) explain(
+	explanation *strings.Builder, path string, keys []string, value any, format func(string, any) string,
+) {
 	if values, ok := value.(map[string]any); ok {
 		for key, val := range values {
 			newPath := path
@@ -193,24 +707,13 @@ func (c *Config) explain(explanation *strings.Builder, path string, value any) {
 				newPath += c.delim()
 			}
 			newPath += key
-			c.explain(explanation, newPath, val)
+			c.explain(explanation, newPath, append(slices.Clip(keys), key), val, format)
 		}
 
 		return
 	}
 
-	type loaderValue struct {
-		loader Loader
-		value  any
-	}
-	var loaders []loaderValue
-	c.providers.traverse(func(provider *provider) {
-		if v := maps.Sub(*provider.values.Load(), c.splitPath(path)); v != nil {
-			loaders = append(loaders, loaderValue{provider.loader, v})
-		}
-	})
-	slices.Reverse(loaders)
-
+	loaders := c.loaderValues(keys)
 	if len(loaders) == 0 {
 		explanation.WriteString(path)
 		explanation.WriteString(" has no configuration.\n\n")
@@ -219,7 +722,7 @@ func (c *Config) explain(explanation *strings.Builder, path string, value any) {
 	}
 	explanation.WriteString(path)
 	explanation.WriteString(" has value[")
-	explanation.WriteString(credential.Blur(path, loaders[0].value))
+	explanation.WriteString(format(path, value)) // The merged value, e.g. by konf.WithMergeFunc.
 	explanation.WriteString("] that is loaded by loader[")
 	explanation.WriteString(fmt.Sprintf("%v", loaders[0].loader))
 	explanation.WriteString("].\n")
@@ -227,7 +730,7 @@ func (c *Config) explain(explanation *strings.Builder, path string, value any) {
 		explanation.WriteString("Here are other value(loader)s:\n")
 		for _, loader := range loaders[1:] {
 			explanation.WriteString("  - ")
-			explanation.WriteString(credential.Blur(path, loader.value))
+			explanation.WriteString(format(path, loader.value))
 			explanation.WriteString("(")
 			explanation.WriteString(fmt.Sprintf("%v", loader.loader))
 			explanation.WriteString(")\n")
@@ -236,30 +739,414 @@ func (c *Config) explain(explanation *strings.Builder, path string, value any) {
 	explanation.WriteString("\n")
 }
 
+// Provenance returns the loader that the value of each leaf path is loaded from.
+// The keys are the paths joined by the delimiter, and the values are the string
+// representation of the loaders that take precedence.
+//
+// This method is concurrent-safe.
+func (c *Config) Provenance() map[string]string {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	provenance := make(map[string]string)
+	keys := c.splitPath("")
+	c.provenance(provenance, "", keys, c.root().providers.sub(keys))
+
+	return provenance
+}
+
+func (c *Config) provenance(provenance map[string]string, path string, keys []string, value any) {
+	_, value = maps.Unpack(value)
+	if values, ok := value.(map[string]any); ok {
+		for key, val := range values {
+			newPath := path
+			if newPath != "" {
+				newPath += c.delim()
+			}
+			newPath += key
+			c.provenance(provenance, newPath, append(slices.Clip(keys), key), val)
+		}
+
+		return
+	}
+
+	if loaders := c.loaderValues(keys); len(loaders) > 0 {
+		provenance[path] = fmt.Sprintf("%v", loaders[0].loader)
+	}
+}
+
+// Range calls fn sequentially for each leaf path and its value in the Config,
+// ordered by the path. The path is joined by the delimiter.
+// If fn returns false, Range stops the iteration.
+//
+// It iterates over a snapshot of the configuration, so it's not affected by
+// the changes from Config.Load or Config.Watch during the iteration.
+//
+// This method is concurrent-safe.
+func (c *Config) Range(fn func(path string, value any) bool) {
+	if c == nil || fn == nil { // To support nil
+		return
+	}
+	c.nocopy.Check()
+
+	values, _ := c.root().providers.sub(c.splitPath("")).(map[string]any)
+	c.rangeValues("", values, fn)
+}
+
+func (c *Config) rangeValues(path string, value any, fn func(string, any) bool) bool {
+	_, value = maps.Unpack(value)
+	values, ok := value.(map[string]any)
+	if !ok {
+		return fn(path, value)
+	}
+
+	// Sort by the path prefix of each key rather than the key itself,
+	// so the leaf paths are ordered lexically, e.g. `a-b` is before `a.b`.
+	prefixes := make(map[string]string, len(values))
+	keys := make([]string, 0, len(values))
+	for key, val := range values {
+		prefixes[key] = key
+		_, val = maps.Unpack(val)
+		if _, ok := val.(map[string]any); ok {
+			prefixes[key] += c.delim()
+		}
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int { return strings.Compare(prefixes[a], prefixes[b]) })
+	for _, key := range keys {
+		newPath := path
+		if newPath != "" {
+			newPath += c.delim()
+		}
+		newPath += key
+		if !c.rangeValues(newPath, values[key], fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Value returns the value of the given path merged from all loaders, and whether the path exists.
+// It distinguishes the missing path (false) from the path with nil value (true).
+// The returned map or slice is a deep copy, so it's safe to modify.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// This method is concurrent-safe.
+func (c *Config) Value(path string) (any, bool) {
+	if c == nil { // To support nil
+		return nil, false
+	}
+	c.nocopy.Check()
+
+	value, ok := c.root().providers.lookup(c.splitPath(path))
+	if !ok {
+		return nil, false
+	}
+
+	return c.copyValue(path, value, false), true
+}
+
+// ToMap returns a deep copy of the configuration as a nested map like `{parent: {child: {key: 1}}}`.
+// The keys are in lower case unless konf.WithCaseSensitive or konf.WithMapKeyCaseSensitive is set.
+//
+// This method is concurrent-safe.
+func (c *Config) ToMap() map[string]any {
+	if c == nil { // To support nil
+		return map[string]any{}
+	}
+	c.nocopy.Check()
+
+	return c.toMap(false)
+}
+
+// Marshal serializes the configuration with the given marshal function (e.g. json.Marshal)
+// and writes it to w. It blurs sensitive information if konf.WithBlurOnMarshal is set.
+//
+// This method is concurrent-safe.
+func (c *Config) Marshal(w io.Writer, marshal func(any) ([]byte, error)) error {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	bytes, err := marshal(c.toMap(c.blurOnMarshal))
+	if err != nil {
+		return fmt.Errorf("marshal configuration: %w", err)
+	}
+	if _, err := w.Write(bytes); err != nil {
+		return fmt.Errorf("write configuration: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Config) toMap(blur bool) map[string]any {
+	values, _ := c.copyValue("", c.root().providers.sub(c.splitPath("")), blur).(map[string]any)
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	return values
+}
+
+func (c *Config) copyValue(path string, value any, blur bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			newPath := path
+			if newPath != "" {
+				newPath += c.delim()
+			}
+			newPath += key
+			// Restore the original key if it's packed for konf.WithMapKeyCaseSensitive.
+			originalKey, val := maps.Unpack(val)
+			if originalKey != "" {
+				key = originalKey
+			}
+			values[key] = c.copyValue(newPath, val, blur)
+		}
+
+		return values
+	case []any:
+		values := make([]any, len(value))
+		for i, val := range value {
+			_, val = maps.Unpack(val)
+			values[i] = c.copyValue(path, val, blur)
+		}
+
+		return values
+	default:
+		if blur {
+			if blurred := c.blurPatterns.Blur(path, value); blurred != fmt.Sprint(value) {
+				return blurred
+			}
+		}
+
+		return value
+	}
+}
+
+// Keys returns all leaf paths in the Config, sorted lexically.
+// The path is joined by the delimiter, e.g. `parent.child.key`,
+// and it's in lower case unless konf.WithCaseSensitive is set.
+//
+// This method is concurrent-safe.
+func (c *Config) Keys() []string {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	var keys []string
+	c.Range(func(path string, _ any) bool {
+		keys = append(keys, path)
+
+		return true
+	})
+
+	return keys
+}
+
+// ExportEnv exports the configuration as environment variables like `PREFIX_PARENT_CHILD='value'`,
+// which helps to reproduce the configuration locally.
+// The keys are upper-cased and joined by the given separator, and prefixed with the given prefix if it's not empty.
+// The slices and maps in slices are encoded as JSON, e.g. `PREFIX_HOSTS='["a","b"]'`.
+// The values are single-quoted for the shell, and the embedded single quotes are escaped.
+// It blurs sensitive information. The result is ordered by the path.
+//
+// This method is concurrent-safe.
+func (c *Config) ExportEnv(prefix, sep string) []string {
+	if c == nil { // To support nil
+		return nil
+	}
+	c.nocopy.Check()
+
+	var envs []string
+	c.Range(func(path string, value any) bool {
+		var formatted any = value
+		switch value.(type) {
+		case []any, map[string]any:
+			if bytes, err := json.Marshal(value); err == nil {
+				formatted = bytes
+			}
+		}
+
+		key := strings.ToUpper(strings.ReplaceAll(path, c.delim(), sep))
+		if prefix != "" {
+			key = prefix + sep + key
+		}
+		envs = append(envs, key+"="+shellQuote(c.blurPatterns.Blur(path, formatted)))
+
+		return true
+	})
+
+	return envs
+}
+
+// shellQuote quotes the value with single quotes so that the shell takes it literally.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+type loaderValue struct {
+	loader Loader
+	value  any
+}
+
+// loaderValues returns the values of the given keys from each loader, ordered by precedence.
+func (c *Config) loaderValues(keys []string) []loaderValue {
+	var loaders []loaderValue
+	c.root().providers.resolve()
+	c.root().providers.traverse(func(provider *provider) {
+		if v := maps.Sub(*provider.values.Load(), keys); v != nil {
+			loaders = append(loaders, loaderValue{provider.loader, v})
+		}
+	})
+	slices.Reverse(loaders)
+
+	return loaders
+}
+
 type (
 	providers struct {
-		providers []*provider
-		values    atomic.Pointer[map[string]any]
-		mutex     sync.RWMutex
+		providers   []*provider
+		values      atomic.Pointer[map[string]any]
+		mutex       sync.RWMutex
+		merge       func(path []string, existing, incoming any) any
+		onDuplicate func(path []string, loaders []string) // For konf.WithDuplicateKeyHandler.
+		pending     atomic.Bool                           // If there are lazy loaders which have not been resolved.
 	}
 	provider struct {
-		loader  Loader
-		values  atomic.Pointer[map[string]any]
-		watched atomic.Bool
+		loader   Loader
+		values   atomic.Pointer[map[string]any]
+		duration atomic.Int64
+		watched  atomic.Bool
+		resolve  func() // For the lazy loader, and it's nil once resolved.
 	}
 )
 
-func (p *providers) append(loader Loader, values map[string]any) *provider {
+func (p *providers) append(providers ...*provider) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
-	provider := &provider{loader: loader}
-	provider.values.Store(&values)
-	p.providers = append(p.providers, provider)
+	p.providers = append(p.providers, providers...)
+	for _, provider := range providers {
+		if provider.resolve != nil {
+			p.pending.Store(true)
+		}
+	}
+	p.sync()
+}
+
+// remove removes the provider of the given loader, and returns whether it's found.
+func (p *providers) remove(loader Loader) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 
+	index := slices.IndexFunc(p.providers, func(provider *provider) bool {
+		return sameLoader(provider.loader, loader)
+	})
+	if index < 0 {
+		return false
+	}
+	p.providers = slices.Delete(p.providers, index, index+1)
 	p.sync()
 
-	return provider
+	return true
+}
+
+// sameLoader reports whether the given loaders are identical.
+// Since most loaders are structs which are not comparable (e.g. env.Env has func fields),
+// it compares the fields recursively, the functions, maps and pointers by identity,
+// and the other values (e.g. the prefix of StripPrefix) by value.
+func sameLoader(a, b Loader) bool {
+	return sameValue(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func sameValue(a, b reflect.Value) bool { //nolint:cyclop
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() { //nolint:exhaustive
+	case reflect.Func, reflect.Map, reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Interface:
+		return sameValue(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if !sameValue(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := range a.Len() {
+			if !sameValue(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	default:
+		return false
+	}
+}
+
+// resolve loads the configuration from the pending lazy loaders.
+// The loaders are loaded outside the lock so that a slow loader does not block other operations, e.g. Config.Load.
+func (p *providers) resolve() {
+	if !p.pending.Load() {
+		return
+	}
+
+	p.mutex.RLock()
+	var pending []*provider
+	var resolves []func()
+	for _, provider := range p.providers {
+		if provider.resolve != nil {
+			pending = append(pending, provider)
+			resolves = append(resolves, provider.resolve)
+		}
+	}
+	p.mutex.RUnlock()
+
+	for _, resolve := range resolves {
+		resolve()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, provider := range pending {
+		provider.resolve = nil
+	}
+	// The other lazy loaders could be appended while resolving.
+	p.pending.Store(slices.ContainsFunc(p.providers, func(provider *provider) bool { return provider.resolve != nil }))
+	p.sync()
 }
 
 func (p *providers) changed() {
@@ -271,10 +1158,34 @@ func (p *providers) changed() {
 
 func (p *providers) sync() {
 	values := make(map[string]any)
+	merge := p.merge
+	var duplicates [][]string
+	if p.onDuplicate != nil {
+		merge = func(path []string, existing, incoming any) any {
+			if !slices.ContainsFunc(duplicates, func(duplicate []string) bool { return slices.Equal(duplicate, path) }) {
+				duplicates = append(duplicates, path)
+			}
+			if p.merge != nil {
+				return p.merge(path, existing, incoming)
+			}
+
+			return incoming
+		}
+	}
 	for _, w := range p.providers {
-		maps.Merge(values, *w.values.Load())
+		maps.MergeWith(values, *w.values.Load(), merge)
 	}
 	p.values.Store(&values)
+
+	for _, path := range duplicates {
+		var loaders []string
+		for _, w := range p.providers {
+			if maps.Sub(*w.values.Load(), path) != nil {
+				loaders = append(loaders, fmt.Sprintf("%v", w.loader))
+			}
+		}
+		p.onDuplicate(path, loaders)
+	}
 }
 
 func (p *providers) traverse(action func(*provider)) {
@@ -287,6 +1198,25 @@ func (p *providers) traverse(action func(*provider)) {
 }
 
 func (p *providers) sub(path []string) any {
+	value, _ := p.lookup(path)
+
+	return value
+}
+
+// lookup works like sub, but also returns whether the path exists.
+func (p *providers) lookup(path []string) (any, bool) {
+	p.resolve()
+
+	val := p.values.Load()
+	if val == nil { // To support zero Config
+		return nil, false
+	}
+
+	return maps.Lookup(*val, path)
+}
+
+// peek works like sub, but it does not resolve the pending lazy loaders.
+func (p *providers) peek(path []string) any {
 	// Here does not need lock since p.values is atomic pointer.
 	// The map of configuration is just swapping in and out,
 	// but the map itself is immutable.
@@ -300,6 +1230,31 @@ func (p *providers) sub(path []string) any {
 	return maps.Sub(*val, path)
 }
 
+// first returns the value of the given path from the provider
+// which takes the highest precedence and has the path.
+func (p *providers) first(path []string) any {
+	p.resolve()
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for i := len(p.providers) - 1; i >= 0; i-- {
+		if value := maps.Sub(*p.providers[i].values.Load(), path); value != nil {
+			return value
+		}
+	}
+
+	return nil
+}
+
+func parseURL(from string) (*url.URL, error) {
+	if from == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	return url.Parse(from) //nolint:wrapcheck
+}
+
 //nolint:gochecknoglobals
 var (
 	defaultTagName = "konf"
@@ -307,14 +1262,47 @@ var (
 
 	defaultHooks = []convert.Option{
 		convert.WithHook[string, time.Duration](time.ParseDuration),
+		convert.WithHook[string, time.Time](timeHook(nil)),
 		convert.WithHook[string, []string](func(f string) ([]string, error) {
 			return strings.Split(f, ","), nil
 		}),
+		convert.WithHook[string, *url.URL](parseURL),
+		convert.WithHook[string, url.URL](func(f string) (url.URL, error) {
+			u, err := parseURL(f)
+			if u == nil {
+				return url.URL{}, err
+			}
+
+			return *u, nil
+		}),
+		convert.WithHook[string, net.IP](func(f string) (net.IP, error) {
+			if f == "" {
+				return nil, nil
+			}
+			if ip := net.ParseIP(f); ip != nil {
+				return ip, nil
+			}
+
+			return nil, fmt.Errorf("invalid IP address %q", f) //nolint:err113
+		}),
+		convert.WithHook[string, netip.Addr](func(f string) (netip.Addr, error) {
+			if f == "" {
+				return netip.Addr{}, nil
+			}
+
+			return netip.ParseAddr(f) //nolint:wrapcheck
+		}),
 		convert.WithHook[string, encoding.TextUnmarshaler](func(f string, t encoding.TextUnmarshaler) error {
 			return t.UnmarshalText(internal.String2ByteSlice(f))
 		}),
+		convert.WithHook[any, json.RawMessage](func(f any) (json.RawMessage, error) {
+			bytes, err := json.Marshal(f)
+			if err != nil {
+				return nil, fmt.Errorf("marshal as JSON: %w", err)
+			}
+
+			return bytes, nil
+		}),
 	}
-	defaultConverter = convert.New(
-		append(defaultHooks, convert.WithTagName(defaultTagName), convert.WithKeyMapper(defaultKeyMap))...,
-	)
+	defaultConvertOpts = append(defaultHooks, convert.WithTagName(defaultTagName), convert.WithKeyMapper(defaultKeyMap))
 )
@@ -4,9 +4,6 @@
 package konf
 
 import (
-	"context"
-	"log/slog"
-	"reflect"
 	"sync/atomic"
 
 	"github.com/nil-go/konf/provider/env"
@@ -16,25 +13,20 @@ import (
 // It returns the zero value of the expected type if there is an error.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
 func Get[T any](path string) T { //nolint:ireturn
-	var value T
-	if err := Unmarshal(path, &value); err != nil {
-		defaultConfig.Load().log(context.Background(),
-			slog.LevelWarn,
-			"Could not read config, return empty value instead.",
-			slog.String("path", path),
-			slog.Any("type", reflect.TypeOf(value)),
-			slog.Any("error", err),
-		)
-	}
-
-	return value
+	return GetFrom[T](getDefault(), path)
 }
 
 // Unmarshal reads configuration under the given path from the default Config
 // and decodes it into the given object pointed to by target.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
 func Unmarshal(path string, target any) error {
-	return defaultConfig.Load().Unmarshal(path, target)
+	return getDefault().Unmarshal(path, target)
+}
+
+// MustUnmarshal works like konf.Unmarshal, but panics if there is an error.
+// See Config.MustUnmarshal for details.
+func MustUnmarshal(path string, target any) {
+	getDefault().MustUnmarshal(path, target)
 }
 
 // OnChange registers a callback function that is executed
@@ -46,14 +38,24 @@ func Unmarshal(path string, target any) error {
 //
 // This method is concurrent-safe.
 func OnChange(onChange func(), paths ...string) {
-	defaultConfig.Load().OnChange(func(*Config) { onChange() }, paths...)
+	getDefault().OnChange(func(*Config) { onChange() }, paths...)
 }
 
 // Explain provides information about how default Config resolve each value
 // from loaders for the given path. It blur sensitive information.
 // The path is case-insensitive unless konf.WithCaseSensitive is set.
 func Explain(path string) string {
-	return defaultConfig.Load().Explain(path)
+	return getDefault().Explain(path)
+}
+
+// SetStrictInit sets whether the Config must be created by konf.New.
+// If it's true, using a zero Config (e.g. `var config konf.Config`) panics
+// in Config.Load, Config.Unmarshal and Config.Watch, which catches the accidental use
+// of an uninitialized Config.
+//
+// By default, it's false, and zero Config is ready to use with default options.
+func SetStrictInit(strict bool) {
+	strictInit.Store(strict)
 }
 
 // SetDefault sets the given Config as the default Config.
@@ -65,11 +67,24 @@ func SetDefault(config *Config) {
 	}
 }
 
-var defaultConfig atomic.Pointer[Config] //nolint:gochecknoglobals
+//nolint:gochecknoglobals
+var (
+	defaultConfig atomic.Pointer[Config]
+	strictInit    atomic.Bool
+)
 
-func init() { //nolint:gochecknoinits
-	var config Config
+// getDefault returns the default Config.
+// The default Config is created at the first use so that it applies the hooks
+// registered by konf.RegisterDecodeHook in the init functions.
+func getDefault() *Config {
+	if config := defaultConfig.Load(); config != nil {
+		return config
+	}
+
+	config := New()
 	// Ignore error: env loader does not return error.
 	_ = config.Load(env.New())
-	defaultConfig.Store(&config)
+	defaultConfig.CompareAndSwap(nil, config)
+
+	return defaultConfig.Load()
 }
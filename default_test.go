@@ -25,6 +25,19 @@ func TestUnmarshal(t *testing.T) {
 	assert.Equal(t, "string", v)
 }
 
+func TestMustUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var config konf.Config
+	err := config.Load(mapLoader{"config": "string"})
+	assert.NoError(t, err)
+	konf.SetDefault(&config)
+
+	var v string
+	konf.MustUnmarshal("config", &v)
+	assert.Equal(t, "string", v)
+}
+
 func TestGet(t *testing.T) {
 	t.Parallel()
 
@@ -104,3 +117,30 @@ Here are other value(loader)s:
 
 `, konf.Explain("config"))
 }
+
+func TestSetStrictInit(t *testing.T) {
+	konf.SetStrictInit(true)
+	defer konf.SetStrictInit(false)
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"config": "string"}))
+	var value string
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, "string", value)
+
+	defer func() {
+		assert.Equal(t, recover(), "illegal use of zero Config, create it with konf.New")
+	}()
+	var zero konf.Config
+	_ = zero.Load(mapLoader{"config": "string"})
+	t.Fail()
+}
+
+func TestSetStrictInit_lenient(t *testing.T) {
+	var config konf.Config
+	assert.NoError(t, config.Load(mapLoader{"config": "string"}))
+
+	var value string
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, "string", value)
+}
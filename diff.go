@@ -0,0 +1,85 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// Diff computes the leaf paths that are added, removed or changed from old to new.
+// Both old and new are nested maps like `{parent: {child: {key: 1}}}`,
+// and the paths are joined with the given delimiter. Each result is sorted.
+//
+// A path whose value changes between a nested map and a leaf value is reported as changed.
+func Diff(old, new map[string]any, delimiter string) (added, removed, changed []string) { //nolint:predeclared
+	var differ differ
+	differ.delimiter = delimiter
+	differ.diff("", old, new)
+
+	slices.Sort(differ.added)
+	slices.Sort(differ.removed)
+	slices.Sort(differ.changed)
+
+	return differ.added, differ.removed, differ.changed
+}
+
+type differ struct {
+	delimiter string
+	added     []string
+	removed   []string
+	changed   []string
+}
+
+func (d *differ) diff(prefix string, old, new map[string]any) { //nolint:predeclared
+	for key, oldValue := range old {
+		path := d.join(prefix, key)
+		newValue, ok := new[key]
+		if !ok {
+			d.removed = d.leaves(d.removed, path, oldValue)
+
+			continue
+		}
+
+		_, oldValue = maps.Unpack(oldValue)
+		_, newValue = maps.Unpack(newValue)
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		switch {
+		case oldIsMap && newIsMap:
+			d.diff(path, oldMap, newMap)
+		case !reflect.DeepEqual(oldValue, newValue):
+			d.changed = append(d.changed, path)
+		}
+	}
+
+	for key, newValue := range new {
+		if _, ok := old[key]; !ok {
+			d.added = d.leaves(d.added, d.join(prefix, key), newValue)
+		}
+	}
+}
+
+func (d *differ) leaves(paths []string, path string, value any) []string {
+	_, value = maps.Unpack(value)
+	values, ok := value.(map[string]any)
+	if !ok {
+		return append(paths, path)
+	}
+	for key, value := range values {
+		paths = d.leaves(paths, d.join(path, key), value)
+	}
+
+	return paths
+}
+
+func (d *differ) join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + d.delimiter + key
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		old         map[string]any
+		new         map[string]any
+		added       []string
+		removed     []string
+		changed     []string
+	}{
+		{
+			description: "nil",
+		},
+		{
+			description: "same",
+			old:         map[string]any{"a": map[string]any{"b": 1}},
+			new:         map[string]any{"a": map[string]any{"b": 1}},
+		},
+		{
+			description: "added",
+			old:         map[string]any{"a": 1},
+			new:         map[string]any{"a": 1, "b": map[string]any{"c": 2, "d": 3}},
+			added:       []string{"b.c", "b.d"},
+		},
+		{
+			description: "removed",
+			old:         map[string]any{"a": 1, "b": map[string]any{"c": 2}},
+			new:         map[string]any{"b": map[string]any{}},
+			removed:     []string{"a", "b.c"},
+		},
+		{
+			description: "nested changed",
+			old:         map[string]any{"a": map[string]any{"b": map[string]any{"c": 1, "d": []any{1}}}},
+			new:         map[string]any{"a": map[string]any{"b": map[string]any{"c": 2, "d": []any{1}}}},
+			changed:     []string{"a.b.c"},
+		},
+		{
+			description: "map to leaf",
+			old:         map[string]any{"a": map[string]any{"b": 1}},
+			new:         map[string]any{"a": 1},
+			changed:     []string{"a"},
+		},
+		{
+			description: "mixed",
+			old:         map[string]any{"a": 1, "b": 2, "c": map[string]any{"d": 3}},
+			new:         map[string]any{"b": 3, "c": map[string]any{"d": 3, "e": 4}},
+			added:       []string{"c.e"},
+			removed:     []string{"a"},
+			changed:     []string{"b"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			added, removed, changed := konf.Diff(testcase.old, testcase.new, ".")
+			assert.Equal(t, testcase.added, added)
+			assert.Equal(t, testcase.removed, removed)
+			assert.Equal(t, testcase.changed, changed)
+		})
+	}
+}
+
+func TestDiff_delimiter(t *testing.T) {
+	t.Parallel()
+
+	added, _, _ := konf.Diff(nil, map[string]any{"a": map[string]any{"b": 1}}, "/")
+	assert.Equal(t, []string{"a/b"}, added)
+}
@@ -80,6 +80,13 @@ This would require an input that looks like below:
 	    "person": map[string]interface{}{"name": "alice"},
 	}
 
+The key of the embedded struct can be renamed with the "konf" tag like other fields,
+e.g. the fields of the embedded struct below are read from the "db" key:
+
+	type Config struct {
+	    DBConfig `konf:"db"`
+	}
+
 If your "person" value is NOT nested, then you can append ",squash" to
 your tag value and konf will treat it as if the embedded struct
 were part of the struct directly. Example:
@@ -94,6 +101,39 @@ Now the following input would be accepted:
 	    "name": "alice",
 	}
 
+# Required Fields
+
+If a field is mandatory, you can append ",required" to your tag value,
+and konf returns an error naming the field path if the key is missing.
+All missing required fields are reported together. Example:
+
+	type Server struct {
+	    Host string `konf:",required"`
+	    Port int    `konf:"port,required"`
+	}
+
+With the following input, it returns error `'port' is required but missing`:
+
+	map[string]interface{}{
+	    "host": "localhost",
+	}
+
+The required fields of a nested struct are only checked if the nested struct has input.
+
+# Default Values
+
+If a field has a default value, you can append ",default=<value>" to your tag value,
+and konf converts the value into the field with the same decode hooks if the key is missing.
+The default value must be the last in the tag value, and it can contain commas. Example:
+
+	type Server struct {
+	    Host    string        `konf:",default=localhost"`
+	    Timeout time.Duration `konf:"timeout,default=30s"`
+	}
+
+It returns an error naming the field path if the default value could not be converted.
+The default values of a nested struct are applied even if the nested struct or the path has no input.
+
 # Unexported fields
 
 Since unexported (private) struct fields cannot be set outside the package
@@ -0,0 +1,36 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// durationHook returns the decode hook which converts both Go duration string (e.g. "5s")
+// and numeric value (e.g. 5 or "5") to time.Duration, while the number is in the given unit.
+func durationHook(unit time.Duration) func(any) (time.Duration, error) {
+	return func(from any) (time.Duration, error) {
+		value := reflect.ValueOf(from)
+		switch {
+		case value.Kind() == reflect.String:
+			str := value.String()
+			if number, err := strconv.ParseFloat(str, 64); err == nil {
+				return time.Duration(number * float64(unit)), nil
+			}
+
+			return time.ParseDuration(str)
+		case value.CanInt():
+			return time.Duration(value.Int()) * unit, nil
+		case value.CanUint():
+			return time.Duration(value.Uint()) * unit, nil //nolint:gosec
+		case value.CanFloat():
+			return time.Duration(value.Float() * float64(unit)), nil
+		default:
+			return 0, errors.ErrUnsupported
+		}
+	}
+}
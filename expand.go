@@ -0,0 +1,114 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expandEnv returns the function which replaces `${VAR}` and `$VAR` in the string
+// with the value of the environment variable, and `$$` with a literal `$`.
+// The unresolved references are left intact, or return an error if strict is true.
+func expandEnv(strict bool) func(string) (string, error) {
+	return func(value string) (string, error) {
+		if !strings.Contains(value, "$") {
+			return value, nil
+		}
+
+		var builder strings.Builder
+		builder.Grow(len(value))
+		for i := 0; i < len(value); i++ {
+			if value[i] != '$' || i+1 == len(value) {
+				builder.WriteByte(value[i])
+
+				continue
+			}
+
+			var name, reference string
+			switch {
+			case value[i+1] == '$':
+				builder.WriteByte('$')
+				i++
+
+				continue
+			case value[i+1] == '{':
+				end := strings.IndexByte(value[i+2:], '}')
+				if end < 0 {
+					// No closing brace, leave the rest as it is.
+					builder.WriteString(value[i:])
+
+					return builder.String(), nil
+				}
+				name, reference = value[i+2:i+2+end], value[i:i+3+end]
+			default:
+				end := i + 1
+				for end < len(value) && isEnvNameChar(value[end]) {
+					end++
+				}
+				name, reference = value[i+1:end], value[i:end]
+			}
+			if name == "" {
+				builder.WriteByte('$')
+
+				continue
+			}
+
+			if env, ok := os.LookupEnv(name); ok {
+				builder.WriteString(env)
+			} else {
+				if strict {
+					return "", fmt.Errorf("environment variable %s is not set", name) //nolint:err113
+				}
+				builder.WriteString(reference)
+			}
+			i += len(reference) - 1
+		}
+
+		return builder.String(), nil
+	}
+}
+
+func isEnvNameChar(char byte) bool {
+	return char == '_' || '0' <= char && char <= '9' || 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z'
+}
+
+// expandValues returns a copy of the values with the environment variables expanded in the string leaves.
+// It does not modify the given values since the loader may return the same values for every load.
+func expandValues(value any, path []string, delimiter string, expand func(string) (string, error)) (any, error) {
+	switch value := value.(type) {
+	case string:
+		expanded, err := expand(value)
+		if err != nil {
+			return nil, fmt.Errorf("expand %s: %w", strings.Join(path, delimiter), err)
+		}
+
+		return expanded, nil
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			expanded, err := expandValues(val, append(path, key), delimiter, expand)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = expanded
+		}
+
+		return values, nil
+	case []any:
+		values := make([]any, len(value))
+		for index, val := range value {
+			expanded, err := expandValues(val, append(path, fmt.Sprint(index)), delimiter, expand)
+			if err != nil {
+				return nil, err
+			}
+			values[index] = expanded
+		}
+
+		return values, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+//nolint:paralleltest // It sets environment variables.
+func TestWithEnvExpand(t *testing.T) {
+	t.Setenv("KONF_HOST", "localhost")
+	t.Setenv("KONF_PORT", "5432")
+
+	testcases := []struct {
+		description string
+		opts        []konf.Option
+		values      map[string]any
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "braces",
+			opts:        []konf.Option{konf.WithEnvExpand()},
+			values:      map[string]any{"dsn": "postgres://${KONF_HOST}:${KONF_PORT}/app"},
+			expected:    map[string]any{"dsn": "postgres://localhost:5432/app"},
+		},
+		{
+			description: "without braces",
+			opts:        []konf.Option{konf.WithEnvExpand()},
+			values:      map[string]any{"dsn": "postgres://$KONF_HOST:$KONF_PORT/app"},
+			expected:    map[string]any{"dsn": "postgres://localhost:5432/app"},
+		},
+		{
+			description: "escape",
+			opts:        []konf.Option{konf.WithEnvExpand()},
+			values:      map[string]any{"price": "$$5 for $$KONF_HOST", "tail": "$", "bare": "$-"},
+			expected:    map[string]any{"price": "$5 for $KONF_HOST", "tail": "$", "bare": "$-"},
+		},
+		{
+			description: "unresolved",
+			opts:        []konf.Option{konf.WithEnvExpand()},
+			values:      map[string]any{"a": "${KONF_UNKNOWN}", "b": "$KONF_UNKNOWN", "c": "${KONF_HOST"},
+			expected:    map[string]any{"a": "${KONF_UNKNOWN}", "b": "$KONF_UNKNOWN", "c": "${KONF_HOST"},
+		},
+		{
+			description: "nested",
+			opts:        []konf.Option{konf.WithEnvExpand()},
+			values: map[string]any{
+				"db":    map[string]any{"host": "${KONF_HOST}", "port": 5432},
+				"hosts": []any{"$KONF_HOST", true},
+			},
+			expected: map[string]any{
+				"db":    map[string]any{"host": "localhost", "port": 5432},
+				"hosts": []any{"localhost", true},
+			},
+		},
+		{
+			description: "strict",
+			opts:        []konf.Option{konf.WithStrictEnvExpand()},
+			values:      map[string]any{"host": "${KONF_HOST}"},
+			expected:    map[string]any{"host": "localhost"},
+		},
+		{
+			description: "strict (unresolved)",
+			opts:        []konf.Option{konf.WithStrictEnvExpand()},
+			values:      map[string]any{"db": map[string]any{"host": "${KONF_UNKNOWN}"}},
+			err:         "load configuration: expand db.host: environment variable KONF_UNKNOWN is not set",
+		},
+		{
+			description: "disabled",
+			values:      map[string]any{"host": "${KONF_HOST}"},
+			expected:    map[string]any{"host": "${KONF_HOST}"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			config := konf.New(testcase.opts...)
+			err := config.Load(mapLoader(testcase.values))
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+
+			var values map[string]any
+			assert.NoError(t, config.Unmarshal("", &values))
+			assert.Equal(t, testcase.expected, values)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nil-go/konf/internal/convert"
+)
+
+// RegisterDecodeHook registers the decode hook for all Config(s), including the default Config
+// and the zero Config. It's useful for libraries which distribute custom types.
+//
+// It can be either `func(F) (T, error)` which returns the converted value,
+// or `func(F, T) error` which sets the converted value inline.
+//
+// The registered hooks are applied before the hooks of the Config (either default hooks
+// or the hooks provided by konf.WithDecodeHook), so they take precedence for the same types.
+// It only affects the Config(s) created by konf.New after this call,
+// so it should be called in the init function.
+//
+// This function is concurrent-safe.
+func RegisterDecodeHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) {
+	globalHooks.mutex.Lock()
+	defer globalHooks.mutex.Unlock()
+
+	globalHooks.hooks = append(globalHooks.hooks, convert.WithHook[F, T](hook))
+	globalHooks.converter.Store(nil) // Reset the converter for zero Config.
+}
+
+func registeredHooks() []convert.Option {
+	globalHooks.mutex.RLock()
+	defer globalHooks.mutex.RUnlock()
+
+	return slices.Clone(globalHooks.hooks)
+}
+
+// zeroConvertOpts returns the convert options for zero Config.
+func zeroConvertOpts() []convert.Option {
+	return append(registeredHooks(), defaultConvertOpts...)
+}
+
+// zeroConverter returns the converter for zero Config.
+func zeroConverter() *convert.Converter {
+	if converter := globalHooks.converter.Load(); converter != nil {
+		return converter
+	}
+
+	globalHooks.mutex.RLock()
+	defer globalHooks.mutex.RUnlock()
+
+	converter := convert.New(append(slices.Clone(globalHooks.hooks), defaultConvertOpts...)...)
+	globalHooks.converter.Store(converter)
+
+	return converter
+}
+
+//nolint:gochecknoglobals
+var globalHooks struct {
+	hooks     []convert.Option
+	converter atomic.Pointer[convert.Converter]
+	mutex     sync.RWMutex
+}
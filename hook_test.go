@@ -0,0 +1,54 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestRegisterDecodeHook(t *testing.T) {
+	konf.RegisterDecodeHook[string, registeredType](func(f string) (registeredType, error) {
+		return registeredType{value: strings.ToUpper(f)}, nil
+	})
+
+	type Config struct {
+		Registered registeredType
+		Duration   string
+	}
+	values := mapLoader{"config": map[string]any{"registered": "value", "duration": "1s"}}
+	expected := Config{Registered: registeredType{value: "VALUE"}, Duration: "1s"}
+
+	// Config created by New.
+	config := konf.New()
+	assert.NoError(t, config.Load(values))
+	var value Config
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, expected, value)
+
+	// Config created by New with decode hooks.
+	config = konf.New(konf.WithDecodeHook[string, int](func(string) (int, error) { return 1, nil }))
+	assert.NoError(t, config.Load(values))
+	value = Config{}
+	assert.NoError(t, config.Unmarshal("config", &value))
+	assert.Equal(t, expected, value)
+
+	// Zero Config.
+	var zero konf.Config
+	assert.NoError(t, zero.Load(values))
+	value = Config{}
+	assert.NoError(t, zero.Unmarshal("config", &value))
+	assert.Equal(t, expected, value)
+
+	// Default Config.
+	konf.SetDefault(config)
+	assert.Equal(t, expected.Registered, konf.Get[registeredType]("config.registered"))
+}
+
+type registeredType struct {
+	value string
+}
@@ -4,9 +4,11 @@
 package convert
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -15,9 +17,14 @@ import (
 )
 
 type Converter struct {
-	hooks   []hook
-	tagName string
-	keyMap  func(string) string
+	hooks       []hook
+	tagName     string
+	keyMap      func(string) string
+	errorUnused bool
+	intBase     int
+	weaklyTyped bool
+
+	ctx context.Context //nolint:containedctx // Only set for a single call of ConvertContext.
 }
 
 func New(opts ...Option) *Converter {
@@ -42,9 +49,30 @@ func (c Converter) Convert(from, to any) error {
 	return c.convert("", from, toVal)
 }
 
+// ConvertContext works like Convert, but checks whether ctx is done at each level of array, map, slice and struct,
+// and returns ctx.Err() if it's done.
+func (c Converter) ConvertContext(ctx context.Context, from, to any) error {
+	c.ctx = ctx
+	err := c.Convert(from, to)
+	if ctx.Err() != nil {
+		return ctx.Err() //nolint:wrapcheck
+	}
+
+	return err
+}
+
+// done returns ctx.Err() of ConvertContext, or nil if it's called by Convert.
+func (c Converter) done() error {
+	if c.ctx == nil {
+		return nil
+	}
+
+	return c.ctx.Err() //nolint:wrapcheck
+}
+
 func (c Converter) convert(name string, from any, toVal reflect.Value) error { //nolint:cyclop,funlen
 	if from == nil {
-		return nil // Do nothing if from is nil.
+		return c.defaults(name, toVal) // Only apply the default values if from is nil.
 	}
 
 	fromVal := reflect.ValueOf(from)
@@ -61,10 +89,27 @@ func (c Converter) convert(name string, from any, toVal reflect.Value) error { /
 
 	for _, h := range c.hooks {
 		if fromVal.Type().AssignableTo(h.fromType) && toVal.Type().AssignableTo(h.toType) {
-			if err := h.hook(fromVal.Interface(), toVal.Interface()); !errors.Is(err, errors.ErrUnsupported) {
-				return err
+			err := h.hook(fromVal.Interface(), toVal.Interface())
+			if errors.Is(err, errors.ErrUnsupported) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("cannot parse '%s' as %s: %w", name, reflect.Indirect(toVal).Type(), err)
 			}
+
+			return nil
+		}
+	}
+
+	if c.weaklyTyped && isSingleElement(fromVal, reflect.Indirect(toVal)) {
+		if fromVal.Len() != 1 {
+			return fmt.Errorf( //nolint:err113
+				"'%s' expected a single element for type '%s', got %d elements",
+				name, reflect.Indirect(toVal).Type(), fromVal.Len(),
+			)
 		}
+
+		return c.convert(name, fromVal.Index(0).Interface(), toVal)
 	}
 
 	toVal = reflect.Indirect(toVal)
@@ -153,7 +198,7 @@ func (c Converter) convertInt(name string, fromVal, toVal reflect.Value) error {
 		if from == "" {
 			toVal.SetInt(0)
 		} else {
-			i, err := strconv.ParseInt(from, 0, toVal.Type().Bits())
+			i, err := strconv.ParseInt(c.intString(from), c.intBase, toVal.Type().Bits())
 			if err != nil {
 				return fmt.Errorf("cannot parse '%s' as int: %w", name, err)
 			}
@@ -202,7 +247,7 @@ func (c Converter) convertUint(name string, fromVal, toVal reflect.Value) error
 		if from == "" {
 			toVal.SetUint(0)
 		} else {
-			i, err := strconv.ParseUint(from, 0, toVal.Type().Bits())
+			i, err := strconv.ParseUint(c.intString(from), c.intBase, toVal.Type().Bits())
 			if err != nil {
 				return fmt.Errorf("cannot parse '%s' as uint: %w", name, err)
 			}
@@ -293,6 +338,10 @@ func (c Converter) convertComplex(name string, fromVal, toVal reflect.Value) err
 }
 
 func (c Converter) convertArray(name string, fromVal, toVal reflect.Value) error {
+	if err := c.done(); err != nil {
+		return err
+	}
+
 	switch fromVal.Kind() {
 	case reflect.Array, reflect.Slice:
 		if fromVal.Len() > toVal.Len() {
@@ -321,6 +370,10 @@ func (c Converter) convertArray(name string, fromVal, toVal reflect.Value) error
 
 			return nil
 		}
+		// Maps with numeric keys turn into arrays ordered by the keys.
+		if values, ok := orderedValues(fromVal); ok {
+			return c.convertArray(name, reflect.ValueOf(values), toVal)
+		}
 
 		fallthrough
 	default:
@@ -332,6 +385,10 @@ func (c Converter) convertArray(name string, fromVal, toVal reflect.Value) error
 }
 
 func (c Converter) convertMap(name string, fromVal, toVal reflect.Value) error {
+	if err := c.done(); err != nil {
+		return err
+	}
+
 	switch fromVal.Kind() {
 	case reflect.Map:
 		if fromVal.IsNil() {
@@ -364,6 +421,7 @@ func (c Converter) convertMap(name string, fromVal, toVal reflect.Value) error {
 			if key == "" {
 				key = fromKeyVal.String()
 			}
+			// Convert the key with the same hooks as values, e.g. encoding.TextUnmarshaler.
 			toKeyVal := reflect.New(toKeyType)
 			if err := c.convert(fieldName, key, pointer(toKeyVal)); err != nil {
 				errs = append(errs, err)
@@ -390,12 +448,22 @@ func (c Converter) convertPointer(name string, fromVal reflect.Value, toVal refl
 		}
 	default:
 	}
-	toVal.Set(reflect.New(toVal.Type().Elem()))
+	// Set the pointer only if the conversion succeeds,
+	// so that it does not point to a zero value on mismatch, e.g. scalar to struct.
+	value := reflect.New(toVal.Type().Elem())
+	if err := c.convert(name, fromVal.Interface(), reflect.Indirect(value)); err != nil {
+		return err
+	}
+	toVal.Set(value)
 
-	return c.convert(name, fromVal.Interface(), reflect.Indirect(toVal.Elem()))
+	return nil
 }
 
 func (c Converter) convertSlice(name string, fromVal, toVal reflect.Value) error { //nolint:cyclop
+	if err := c.done(); err != nil {
+		return err
+	}
+
 	switch {
 	case fromVal.Kind() == reflect.Array || fromVal.Kind() == reflect.Slice:
 		if fromVal.Len() == 0 {
@@ -431,6 +499,10 @@ func (c Converter) convertSlice(name string, fromVal, toVal reflect.Value) error
 
 			return nil
 		}
+		// Maps with numeric keys turn into slices ordered by the keys.
+		if values, ok := orderedValues(fromVal); ok {
+			return c.convertSlice(name, reflect.ValueOf(values), toVal)
+		}
 
 		fallthrough
 	default:
@@ -478,6 +550,10 @@ func (c Converter) convertString(name string, fromVal, toVal reflect.Value) erro
 }
 
 func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) error { //nolint:cyclop,funlen,gocognit
+	if err := c.done(); err != nil {
+		return err
+	}
+
 	switch fromVal.Kind() {
 	case reflect.Map:
 		if fromVal.Type().Key().Kind() != reflect.String {
@@ -493,6 +569,15 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 		structs := make([]reflect.Value, 0, 5) //nolint:mnd
 		structs = append(structs, toVal)
 
+		// It keeps track of the keys that have been used if it needs to report unused keys.
+		var (
+			usedKeys map[string]struct{}
+			unused   unusedKeysError
+		)
+		if c.errorUnused {
+			usedKeys = make(map[string]struct{}, fromVal.Len())
+		}
+
 		var errs []error
 		for len(structs) > 0 {
 			structVal := structs[0]
@@ -509,11 +594,12 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 				}
 
 				// It always parse the tags cause it's looking for other tags too
-				fieldName, tag, _ := strings.Cut(fieldType.Tag.Get(c.tagName), ",")
+				tag := parseTag(fieldType.Tag.Get(c.tagName))
+				fieldName := tag.name
 				if fieldName == "" {
 					fieldName = fieldType.Name
 				}
-				if tag == "squash" {
+				if tag.squash {
 					if fieldVal.Kind() != reflect.Struct {
 						errs = append(errs, fmt.Errorf( //nolint:err113
 							"%s: unsupported type for squash: %s",
@@ -531,27 +617,161 @@ func (c Converter) convertStruct(name string, fromVal, toVal reflect.Value) erro
 					keyName = c.keyMap(keyName)
 				}
 				elemVal := fromVal.MapIndex(reflect.ValueOf(keyName))
+				if usedKeys != nil {
+					usedKeys[keyName] = struct{}{}
+				}
+				if name != "" {
+					fieldName = name + "." + fieldName
+				}
 				if !elemVal.IsValid() {
 					// There was no matching key in the map for the value in the struct.
+					if err := c.fieldDefault(fieldName, tag, fieldVal); err != nil {
+						errs = append(errs, err)
+					}
+
 					continue
 				}
 
-				if name != "" {
-					fieldName = name + "." + fieldName
-				}
 				_, value := maps.Unpack(elemVal.Interface())
+				isNil := fieldVal.Kind() == reflect.Pointer && fieldVal.IsNil()
 				if err := c.convert(fieldName, value, pointer(fieldVal)); err != nil {
-					errs = append(errs, err)
+					if isNil {
+						// Reset the pointer allocated by pointer(), so it does not point to a zero value on mismatch.
+						fieldVal.SetZero()
+					}
+					// Aggregate the unused keys of nested structs into a single error.
+					if unused, err = extractUnused(unused, err); err != nil {
+						errs = append(errs, err)
+					}
 				}
 			}
 		}
 
+		if usedKeys != nil {
+			unused = append(unused, unusedKeys(name, fromVal, usedKeys)...)
+		}
+		if len(unused) > 0 {
+			slices.Sort(unused)
+			errs = append(errs, unused)
+		}
+
 		return errors.Join(errs...)
 	default:
 		return fmt.Errorf("'%s' expected a map, got '%s'", name, fromVal.Kind()) //nolint:err113
 	}
 }
 
+// defaults applies the default values in the tags of the struct fields recursively,
+// and reports the required fields as missing, since the struct has no input, e.g. the parent section is missing.
+func (c Converter) defaults(name string, toVal reflect.Value) error {
+	toVal = reflect.Indirect(toVal)
+	if toVal.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []error
+	structType := toVal.Type()
+	for i := range structType.NumField() {
+		fieldType := structType.Field(i)
+		fieldVal := toVal.Field(i)
+		if !fieldVal.CanSet() {
+			continue
+		}
+
+		tag := parseTag(fieldType.Tag.Get(c.tagName))
+		if tag.squash {
+			if err := c.defaults(name, fieldVal); err != nil {
+				errs = append(errs, err)
+			}
+
+			continue
+		}
+
+		fieldName := tag.name
+		if fieldName == "" {
+			fieldName = fieldType.Name
+		}
+		if name != "" {
+			fieldName = name + "." + fieldName
+		}
+		if err := c.fieldDefault(fieldName, tag, fieldVal); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fieldDefault applies the default value of the struct field whose key is missing.
+// It reports the error if the field is required, and converts the default literal if the tag has it,
+// e.g. `konf:"timeout,default=30s"`, or applies the default values of the nested struct.
+func (c Converter) fieldDefault(name string, tag fieldTag, fieldVal reflect.Value) error {
+	var errs []error
+	if tag.required {
+		errs = append(errs, fmt.Errorf("'%s' is required but missing", name)) //nolint:err113
+	}
+
+	switch {
+	case tag.hasDefault:
+		isNil := fieldVal.Kind() == reflect.Pointer && fieldVal.IsNil()
+		if err := c.convert(name, tag.defaultValue, pointer(fieldVal)); err != nil {
+			if isNil {
+				fieldVal.SetZero()
+			}
+			errs = append(errs, fmt.Errorf("invalid default of '%s': %w", name, err))
+		}
+	case fieldVal.Kind() == reflect.Struct:
+		if err := c.defaults(name, fieldVal); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// fieldTag is the parsed tag of the struct field, e.g. `konf:"name,squash,required,default=value"`.
+type fieldTag struct {
+	name         string
+	squash       bool
+	required     bool
+	hasDefault   bool
+	defaultValue string
+}
+
+func parseTag(tag string) fieldTag {
+	name, options, _ := strings.Cut(tag, ",")
+	parsed := fieldTag{name: name}
+	for options != "" {
+		// The default value takes the rest of the tag since it may contain commas.
+		if value, ok := strings.CutPrefix(options, "default="); ok {
+			parsed.hasDefault, parsed.defaultValue = true, value
+
+			break
+		}
+
+		var option string
+		option, options, _ = strings.Cut(options, ",")
+		switch option {
+		case "squash":
+			parsed.squash = true
+		case "required":
+			parsed.required = true
+		}
+	}
+
+	return parsed
+}
+
+// intString removes the underscore digit separators if the base is fixed,
+// since strconv only accepts them when the base is auto-detected.
+func (c Converter) intString(from string) string {
+	if c.intBase == 0 {
+		return from
+	}
+
+	return strings.ReplaceAll(from, "_", "")
+}
+
 func (c Converter) convertInterface(name string, fromVal, toVal reflect.Value) error {
 	// Copy the value from map and slice to avoid the original value being modified.
 	switch fromVal.Kind() {
@@ -582,6 +802,104 @@ func (c Converter) convertInterface(name string, fromVal, toVal reflect.Value) e
 	return nil
 }
 
+func unusedKeys(name string, fromVal reflect.Value, usedKeys map[string]struct{}) []string {
+	var keys []string
+	for _, keyVal := range fromVal.MapKeys() {
+		if _, ok := usedKeys[keyVal.String()]; ok {
+			continue
+		}
+
+		key, _ := maps.Unpack(fromVal.MapIndex(keyVal).Interface())
+		if key == "" {
+			key = keyVal.String()
+		}
+		if name != "" {
+			key = name + "." + key
+		}
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// unusedKeysError reports the keys that are not used by any field of the struct.
+type unusedKeysError []string
+
+func (e unusedKeysError) Error() string {
+	return "invalid keys: " + strings.Join(e, ", ")
+}
+
+// extractUnused moves the unused keys in the given error (including joined errors) into unused,
+// and returns the rest of the error.
+func extractUnused(unused unusedKeysError, err error) (unusedKeysError, error) {
+	switch e := err.(type) { //nolint:errorlint
+	case unusedKeysError:
+		return append(unused, e...), nil
+	case interface{ Unwrap() []error }:
+		var errs []error
+		for _, err := range e.Unwrap() {
+			if unused, err = extractUnused(unused, err); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return unused, errors.Join(errs...)
+	default:
+		return unused, err
+	}
+}
+
+// orderedValues returns the values of the map ordered by the numeric keys, e.g. `{"0": a, "1": b}`.
+// The gaps between keys are dropped, e.g. `{"0": a, "2": c}` turns into `[a, c]`.
+// It returns false if any key of the map is not a non-negative integer.
+func orderedValues(fromVal reflect.Value) ([]any, bool) {
+	if fromVal.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	type indexedValue struct {
+		index int
+		value any
+	}
+	indexedValues := make([]indexedValue, 0, fromVal.Len())
+	for _, key := range fromVal.MapKeys() {
+		index, err := strconv.Atoi(key.String())
+		if err != nil || index < 0 {
+			return nil, false
+		}
+		_, value := maps.Unpack(fromVal.MapIndex(key).Interface())
+		indexedValues = append(indexedValues, indexedValue{index: index, value: value})
+	}
+	slices.SortFunc(indexedValues, func(a, b indexedValue) int { return a.index - b.index })
+
+	values := make([]any, 0, len(indexedValues))
+	for _, value := range indexedValues {
+		values = append(values, value.value)
+	}
+
+	return values, true
+}
+
+// isSingleElement returns whether the non-empty slice/array should be unwrapped into the scalar.
+// The []byte to string is excluded since it's converted directly.
+func isSingleElement(fromVal, toVal reflect.Value) bool {
+	if fromVal.Kind() != reflect.Array && fromVal.Kind() != reflect.Slice || fromVal.Len() == 0 {
+		return false
+	}
+
+	switch toVal.Kind() {
+	case reflect.String:
+		return fromVal.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	default:
+		return false
+	}
+}
+
 func pointer(val reflect.Value) reflect.Value {
 	if val.Kind() == reflect.Pointer {
 		if val.IsNil() {
@@ -4,10 +4,13 @@
 package convert_test
 
 import (
+	"context"
 	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -144,6 +147,48 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:       pointer(Unknown),
 			expected: pointer(Sky),
 		},
+		// Weakly typed input.
+		{
+			description: "single element slice to int (weakly typed)",
+			opts:        []convert.Option{convert.WithWeaklyTypedInput()},
+			from:        []any{"42"},
+			to:          pointer(0),
+			expected:    pointer(42),
+		},
+		{
+			description: "single element array to string (weakly typed)",
+			opts:        []convert.Option{convert.WithWeaklyTypedInput()},
+			from:        [1]string{"str"},
+			to:          pointer(""),
+			expected:    pointer("str"),
+		},
+		{
+			description: "single element slice to pointer (weakly typed)",
+			opts:        []convert.Option{convert.WithWeaklyTypedInput()},
+			from:        []bool{true},
+			to:          pointer((*bool)(nil)),
+			expected:    pointer(pointer(true)),
+		},
+		{
+			description: "bytes to string (weakly typed)",
+			opts:        []convert.Option{convert.WithWeaklyTypedInput()},
+			from:        []byte("str"),
+			to:          pointer(""),
+			expected:    pointer("str"),
+		},
+		{
+			description: "multiple elements slice to int (weakly typed)",
+			opts:        []convert.Option{convert.WithWeaklyTypedInput()},
+			from:        []int{1, 2},
+			to:          pointer(0),
+			err:         "'' expected a single element for type 'int', got 2 elements",
+		},
+		{
+			description: "single element slice to int",
+			from:        []int{1},
+			to:          pointer(0),
+			err:         "'' expected type 'int', got unconvertible type '[]int', value: '[1]'",
+		},
 		// To bool.
 		{
 			description: "bool to bool",
@@ -284,6 +329,33 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer(0),
 			expected:    pointer(42),
 		},
+		{
+			description: "string to int (with separators)",
+			from:        "1_000",
+			to:          pointer(0),
+			expected:    pointer(1000),
+		},
+		{
+			description: "string to int (with base)",
+			opts:        []convert.Option{convert.WithIntBase(16)},
+			from:        "ff",
+			to:          pointer(0),
+			expected:    pointer(255),
+		},
+		{
+			description: "string to int (with base and separators)",
+			opts:        []convert.Option{convert.WithIntBase(16)},
+			from:        "ff_ff",
+			to:          pointer(0),
+			expected:    pointer(65535),
+		},
+		{
+			description: "string to uint (with base)",
+			opts:        []convert.Option{convert.WithIntBase(2)},
+			from:        "1010",
+			to:          pointer(uint(0)),
+			expected:    pointer(uint(10)),
+		},
 		{
 			description: "string to int (empty)",
 			from:        "",
@@ -588,6 +660,12 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer([3]int{3, 2, 1}),
 			expected:    pointer([3]int{1, 2}),
 		},
+		{
+			description: "map with numeric keys to array",
+			from:        map[string]any{"1": "y", "0": "x"},
+			to:          pointer([2]string{}),
+			expected:    pointer([2]string{"x", "y"}),
+		},
 		{
 			description: "slice to array (too big)",
 			from:        make([]string, 2),
@@ -643,6 +721,18 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer(map[int]*int{0: pointer(40), 1: pointer(1)}),
 			expected:    pointer(map[int]*int{2: pointer(42)}),
 		},
+		{
+			description: "map to http.Header",
+			from:        map[string]any{"Accept": []any{"text/plain", "text/html"}, "X-Request-Id": "id"},
+			to:          pointer(http.Header(nil)),
+			expected:    pointer(http.Header{"Accept": {"text/plain", "text/html"}, "X-Request-Id": {"id"}}),
+		},
+		{
+			description: "map to url.Values",
+			from:        map[string]any{"q": "konf", "tag": []string{"go", "config"}},
+			to:          pointer(url.Values(nil)),
+			expected:    pointer(url.Values{"q": {"konf"}, "tag": {"go", "config"}}),
+		},
 		{
 			description: "unsupported type to map",
 			from:        "str",
@@ -655,6 +745,28 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer(map[uint]uint(nil)),
 			err:         "cannot parse '[-2]' as uint: strconv.ParseUint: parsing \"-2\": invalid syntax",
 		},
+		{
+			description: "map to map (text unmarshaler key)",
+			opts: []convert.Option{
+				convert.WithHook[string, encoding.TextUnmarshaler](func(f string, t encoding.TextUnmarshaler) error {
+					return t.UnmarshalText([]byte(f))
+				}),
+			},
+			from:     map[string]any{"en": "English", "fr": "French"},
+			to:       pointer(map[LangCode]string(nil)),
+			expected: pointer(map[LangCode]string{"en": "English", "fr": "French"}),
+		},
+		{
+			description: "map to map (text unmarshaler key error)",
+			opts: []convert.Option{
+				convert.WithHook[string, encoding.TextUnmarshaler](func(f string, t encoding.TextUnmarshaler) error {
+					return t.UnmarshalText([]byte(f))
+				}),
+			},
+			from: map[string]any{"english": "English"},
+			to:   pointer(map[LangCode]string(nil)),
+			err:  "cannot parse '[english]' as convert_test.LangCode: invalid language code \"english\"",
+		},
 		{
 			description: "map to map (value convert error)",
 			from:        map[string]int{"2": -42},
@@ -747,6 +859,30 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer([]OuterStruct{}),
 			expected:    pointer([]OuterStruct{{OuterField: "v"}}),
 		},
+		{
+			description: "map with numeric keys to slice",
+			from:        map[string]any{"1": "y", "0": "x", "10": "z"},
+			to:          pointer([]string{}),
+			expected:    pointer([]string{"x", "y", "z"}),
+		},
+		{
+			description: "map with numeric keys to slice (has gaps)",
+			from:        map[string]any{"0": "x", "2": "z"},
+			to:          pointer([]string{}),
+			expected:    pointer([]string{"x", "z"}),
+		},
+		{
+			description: "map with numeric keys to slice (struct element)",
+			from:        map[string]any{"0": map[string]any{"OuterField": "x"}, "1": map[string]any{"OuterField": "y"}},
+			to:          pointer([]OuterStruct{}),
+			expected:    pointer([]OuterStruct{{OuterField: "x"}, {OuterField: "y"}}),
+		},
+		{
+			description: "map with negative keys to slice",
+			from:        map[string]any{"-1": "x"},
+			to:          pointer([]map[string]string{}),
+			expected:    pointer([]map[string]string{{"-1": "x"}}),
+		},
 		{
 			description: "int to slice",
 			from:        42,
@@ -879,6 +1015,23 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			}{}),
 			err: "cannot parse 'InnerField', -42 overflows uint",
 		},
+		{
+			description: "map to struct (with renamed embedded struct)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{
+				"innerfield": "top",
+				"inner":      map[string]any{"innerfield": "inner"},
+			},
+			to: pointer(struct {
+				InnerStruct `konf:"inner"`
+			}{}),
+			expected: pointer(struct {
+				InnerStruct `konf:"inner"`
+			}{InnerStruct: InnerStruct{InnerField: "inner"}}),
+		},
 		{
 			description: "squash on field",
 			opts: []convert.Option{
@@ -890,6 +1043,231 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			}{}),
 			err: "InnerField: unsupported type for squash: string",
 		},
+		{
+			description: "map to struct (with error unused)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+				convert.WithErrorUnused(),
+			},
+			from: map[string]any{
+				"innerfield": "squash",
+				"outerfield": "outer",
+				"inner":      map[string]any{"innerfield": "inner"},
+			},
+			to: pointer(OuterStruct{}),
+			expected: pointer(OuterStruct{
+				OuterField:  "outer",
+				InnerStruct: InnerStruct{InnerField: "squash"},
+				Inner:       &InnerStruct{InnerField: "inner"},
+			}),
+		},
+		{
+			description: "map to struct (with error unused, has unused keys)",
+			opts: []convert.Option{
+				convert.WithKeyMapper(strings.ToLower),
+				convert.WithErrorUnused(),
+			},
+			from: map[string]any{
+				"databse": "db",
+				"inner":   map[string]any{"innerfield": "inner", "outerfield": "outer"},
+			},
+			to: pointer(struct {
+				Database string
+				Inner    InnerStruct
+			}{}),
+			err: "invalid keys: Inner.outerfield, databse",
+		},
+		{
+			description: "map to struct (with error unused, has unused keys in nested slice and other errors)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+				convert.WithErrorUnused(),
+			},
+			from: map[string]any{
+				"innerfield": "squash",
+				"outerfield": []string{"str"},
+				"foo":        "foo",
+				"inners":     []any{map[string]any{"innerfield": "inner", "baz": "baz"}},
+			},
+			to: pointer(struct {
+				InnerStruct `konf:",squash"`
+				OuterField  string
+				Inners      []InnerStruct
+			}{}),
+			err: "'OuterField' expected type 'string', got unconvertible type '[]string', value: '[str]'\n" +
+				"invalid keys: Inners[0].baz, foo",
+		},
+		{
+			description: "map to struct (with required fields)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{
+				"host":  "localhost",
+				"inner": map[string]any{},
+			},
+			to: pointer(struct {
+				Host  string `konf:",required"`
+				Port  int    `konf:"port,required"`
+				Inner struct {
+					InnerField string `konf:",required"`
+				}
+			}{}),
+			err: "'port' is required but missing\n'Inner.InnerField' is required but missing",
+		},
+		{
+			description: "map to struct (with required fields in missing nested struct)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{"host": "localhost"},
+			to: pointer(struct {
+				Host  string `konf:",required"`
+				Inner struct {
+					InnerField string `konf:",required"`
+					Optional   string
+				}
+			}{}),
+			err: "'Inner.InnerField' is required but missing",
+		},
+		{
+			description: "nil to struct (with required fields)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+			},
+			to: pointer(struct {
+				Host  string `konf:"host,required"`
+				Inner struct {
+					InnerField string `konf:",required,default=v"`
+				}
+			}{}),
+			err: "'host' is required but missing\n'Inner.InnerField' is required but missing",
+		},
+		{
+			description: "map to struct (with required fields, all present)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{"host": "localhost"},
+			to: pointer(struct {
+				Host string `konf:",required"`
+			}{}),
+			expected: pointer(struct {
+				Host string `konf:",required"`
+			}{Host: "localhost"}),
+		},
+		{
+			description: "map to struct (with default fields)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+				convert.WithHook[string, time.Duration](time.ParseDuration),
+			},
+			from: map[string]any{"host": "example.com"},
+			to: pointer(struct {
+				Host    string        `konf:",default=localhost"`
+				Port    int           `konf:"port,default=8080"`
+				Timeout time.Duration `konf:",default=30s"`
+				Name    string
+			}{}),
+			expected: pointer(struct {
+				Host    string        `konf:",default=localhost"`
+				Port    int           `konf:"port,default=8080"`
+				Timeout time.Duration `konf:",default=30s"`
+				Name    string
+			}{Host: "example.com", Port: 8080, Timeout: 30 * time.Second}),
+		},
+		{
+			description: "map to struct (with default fields in missing nested struct)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{},
+			to: pointer(struct {
+				Server struct {
+					Host        string `konf:",default=localhost"`
+					InnerStruct `konf:",squash"`
+				}
+				Inner *struct {
+					Port int `konf:",default=8080"`
+				}
+			}{}),
+			expected: pointer(struct {
+				Server struct {
+					Host        string `konf:",default=localhost"`
+					InnerStruct `konf:",squash"`
+				}
+				Inner *struct {
+					Port int `konf:",default=8080"`
+				}
+			}{Server: struct {
+				Host        string `konf:",default=localhost"`
+				InnerStruct `konf:",squash"`
+			}{Host: "localhost"}}),
+		},
+		{
+			description: "nil to struct (with default fields)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+			},
+			to: pointer(struct {
+				Port  int `konf:",default=8080"`
+				Inner struct {
+					Port int `konf:",default=http"`
+				}
+			}{}),
+			err: "invalid default of 'Inner.Port': cannot parse 'Inner.Port' as int: strconv.ParseInt: parsing \"http\": invalid syntax",
+		},
+		{
+			description: "map to struct (with invalid default)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{},
+			to: pointer(struct {
+				Port int `konf:",default=http"`
+			}{}),
+			err: "invalid default of 'Port': cannot parse 'Port' as int: strconv.ParseInt: parsing \"http\": invalid syntax",
+		},
+		{
+			description: "map to struct (with combined tag options)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{"innerfield": "squash"},
+			to: pointer(struct {
+				InnerStruct `konf:"inner,squash,required"`
+				Host        string `konf:"host,required,default=localhost"`
+				Name        string `konf:",default=a,b"`
+			}{}),
+			err: "'host' is required but missing",
+		},
+		{
+			description: "map to struct (with combined tag options, all present)",
+			opts: []convert.Option{
+				convert.WithTagName("konf"),
+				convert.WithKeyMapper(strings.ToLower),
+			},
+			from: map[string]any{"innerfield": "squash", "host": "example.com"},
+			to: pointer(struct {
+				InnerStruct `konf:"inner,squash,required"`
+				Host        string `konf:"host,required,default=localhost"`
+				Name        string `konf:",default=a,b"`
+			}{}),
+			expected: pointer(struct {
+				InnerStruct `konf:"inner,squash,required"`
+				Host        string `konf:"host,required,default=localhost"`
+				Name        string `konf:",default=a,b"`
+			}{InnerStruct: InnerStruct{InnerField: "squash"}, Host: "example.com", Name: "a,b"}),
+		},
 		{
 			description: "unsupported key type to struct",
 			from:        map[int]string{},
@@ -902,6 +1280,20 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 			to:          pointer(OuterStruct{}),
 			err:         "'' expected a map, got 'string'",
 		},
+		{
+			description: "nested scalar to struct",
+			opts:        []convert.Option{convert.WithKeyMapper(strings.ToLower)},
+			from:        map[string]any{"inner": 42},
+			to:          pointer(OuterStruct{}),
+			err:         "'Inner' expected a map, got 'int'",
+		},
+		{
+			description: "nested map to scalar",
+			opts:        []convert.Option{convert.WithKeyMapper(strings.ToLower)},
+			from:        map[string]any{"inner": map[string]any{"innerfield": map[string]any{"k": true}}},
+			to:          pointer(OuterStruct{}),
+			err:         "'Inner.InnerField' expected type 'string', got unconvertible type 'map[string]interface {}', value: 'map[k:true]'",
+		},
 		{
 			description: "int to interface",
 			from:        42,
@@ -1053,6 +1445,34 @@ func TestConverter(t *testing.T) { //nolint:maintidx
 	}
 }
 
+func TestConverter_pointer_mismatch(t *testing.T) {
+	t.Parallel()
+
+	var value struct {
+		Inner *InnerStruct
+	}
+	converter := convert.New(convert.WithKeyMapper(strings.ToLower))
+	err := converter.Convert(map[string]any{"inner": true}, &value)
+	assert.EqualError(t, err, "'Inner' expected a map, got 'bool'")
+	assert.Equal(t, nil, value.Inner)
+}
+
+func TestConverter_ConvertContext(t *testing.T) {
+	t.Parallel()
+
+	converter := convert.New()
+	var value map[string][]int
+	assert.NoError(t, converter.ConvertContext(context.Background(), map[string]any{"k": []int{1}}, &value))
+	assert.Equal(t, map[string][]int{"k": {1}}, value)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	value = nil
+	err := converter.ConvertContext(ctx, map[string]any{"k": []int{1}}, &value)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, nil, value)
+}
+
 func pointer[T any](v T) *T { return &v }
 
 type Enum int
@@ -1076,6 +1496,17 @@ func (e *Enum) UnmarshalText(text []byte) error {
 	return nil
 }
 
+type LangCode string
+
+func (l *LangCode) UnmarshalText(text []byte) error {
+	if len(text) != 2 { //nolint:mnd
+		return fmt.Errorf("invalid language code %q", text) //nolint:err113
+	}
+	*l = LangCode(text)
+
+	return nil
+}
+
 type (
 	OuterStruct struct {
 		Enum           Enum
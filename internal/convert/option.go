@@ -20,6 +20,24 @@ func WithKeyMapper(keyMap func(string) string) Option {
 	}
 }
 
+func WithErrorUnused() Option {
+	return func(options *options) {
+		options.errorUnused = true
+	}
+}
+
+func WithIntBase(base int) Option {
+	return func(options *options) {
+		options.intBase = base
+	}
+}
+
+func WithWeaklyTypedInput() Option {
+	return func(options *options) {
+		options.weaklyTyped = true
+	}
+}
+
 func WithHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Option {
 	switch hookFunc := any(hook).(type) {
 	case func(F) (T, error):
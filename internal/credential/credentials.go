@@ -10,30 +10,49 @@ import (
 	"github.com/nil-go/konf/internal"
 )
 
-func Blur(name string, value any) string {
+// Patterns are the additional patterns for blurring besides the built-in patterns.
+type Patterns struct {
+	Names  []*regexp.Regexp
+	Values map[string]*regexp.Regexp
+}
+
+func (p Patterns) Blur(name string, value any) string {
 	if namePattern.MatchString(name) {
 		return "******"
 	}
-
-	var formatted string
-	switch v := value.(type) {
-	case string:
-		formatted = v
-	case []byte:
-		formatted = internal.ByteSlice2String(v)
-	default:
-		formatted = fmt.Sprint(value)
+	for _, pattern := range p.Names {
+		if pattern.MatchString(name) {
+			return "******"
+		}
 	}
 
+	formatted := Format(value)
 	for name, pattern := range secretsPatterns {
 		if pattern.MatchString(formatted) {
 			return name
 		}
 	}
+	for name, pattern := range p.Values {
+		if pattern.MatchString(formatted) {
+			return name
+		}
+	}
 
 	return formatted
 }
 
+// Format formats the value as string without blurring.
+func Format(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return internal.ByteSlice2String(v)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
 //nolint:gochecknoglobals,lll
 var (
 	namePattern     = regexp.MustCompile(`(?i)password|passwd|pass|pwd|pw|secret|token|apiKey|bearer|cred`)
@@ -46,6 +65,8 @@ var (
 		"AWS API Key":                      regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
 		"Amazon MWS Auth Token":            regexp.MustCompile(`amzn\.mws\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`),
 		"AWS AppSync GraphQL Key":          regexp.MustCompile(`da2-[a-z0-9]{26}`),
+		"Azure SAS Token":                  regexp.MustCompile(`[?&]sig=[0-9A-Za-z%+/=]{16,}`),
+		"Azure Connection String":          regexp.MustCompile(`(?i)(AccountKey|SharedAccessKey)=[0-9A-Za-z+/=]{16,}`),
 		"GitHub personal access token":     regexp.MustCompile(`ghp_[a-zA-Z0-9]{36}`),
 		"GitHub fine-grained access token": regexp.MustCompile(`github_pat_[a-zA-Z0-9]{22}_[a-zA-Z0-9]{59}`),
 		"GitHub action temporary token":    regexp.MustCompile(`ghs_[a-zA-Z0-9]{36}`),
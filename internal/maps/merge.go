@@ -3,30 +3,49 @@
 
 package maps
 
+import "slices"
+
 // Merge recursively merges the src map into the dst map.
 // Key conflicts are resolved by preferring src,
 // or recursively descending, if both values from src and dst are map.
 func Merge(dst, src map[string]any) {
+	MergeWith(dst, src, nil)
+}
+
+// MergeWith works like Merge, but resolves key conflicts with the given merge function
+// unless both values from src and dst are map. The merge function receives the full key path,
+// the existing value from dst and the incoming value from src, and returns the merged value.
+// If the merge function is nil, it prefers src.
+func MergeWith(dst, src map[string]any, merge func(path []string, existing, incoming any) any) {
+	mergeWith(dst, src, nil, merge)
+}
+
+func mergeWith(dst, src map[string]any, path []string, merge func(path []string, existing, incoming any) any) {
 	for key, srcVal := range src {
-		// Direct override if the srcVal is not map[string]any.
+		dstVal, exists := dst[key]
+
+		// Merge if the srcVal and dstVal are both map[string]any.
 		srcMap, srcOk := srcVal.(map[string]any)
-		if !srcOk {
-			dst[key] = srcVal
+		dstMap, dstOk := dstVal.(map[string]any)
+		if srcOk && dstOk {
+			mergeWith(dstMap, srcMap, append(path, key), merge)
 
 			continue
 		}
 
-		// Direct override if the dstVal is not map[string]any.
-		dstMap, dstOk := dst[key].(map[string]any)
-		if !dstOk {
+		// Copy the srcVal if it's map[string]any, so the dst map does not share it with src.
+		if srcOk {
 			values := make(map[string]any)
 			Merge(values, srcMap)
-			dst[key] = values
+			srcVal = values
+		}
+
+		// Direct override if there is no merge function.
+		if !exists || merge == nil {
+			dst[key] = srcVal
 
 			continue
 		}
-
-		// Merge if the srcVal and dstVal are both map[string]any.
-		Merge(dstMap, srcMap)
+		dst[key] = merge(append(slices.Clone(path), key), dstVal, srcVal)
 	}
 }
@@ -4,6 +4,8 @@
 package maps_test
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/nil-go/konf/internal/assert"
@@ -84,3 +86,34 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeWith(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	merge := func(path []string, existing, incoming any) any {
+		paths = append(paths, strings.Join(path, "."))
+		existingSlice, existingOk := existing.([]any)
+		incomingSlice, incomingOk := incoming.([]any)
+		if existingOk && incomingOk {
+			return slices.Concat(existingSlice, incomingSlice)
+		}
+
+		return incoming
+	}
+
+	dst := map[string]any{
+		"a": map[string]any{"x": []any{1}, "y": 1},
+		"b": []any{"b"},
+	}
+	maps.MergeWith(dst, map[string]any{
+		"a": map[string]any{"x": []any{2}, "y": 2, "z": 3},
+		"b": map[string]any{"c": 1},
+	}, merge)
+	assert.Equal(t, map[string]any{
+		"a": map[string]any{"x": []any{1, 2}, "y": 2, "z": 3},
+		"b": map[string]any{"c": 1},
+	}, dst)
+	slices.Sort(paths)
+	assert.Equal(t, []string{"a.x", "a.y", "b"}, paths)
+}
@@ -3,22 +3,54 @@
 
 package maps
 
-import "slices"
+import (
+	"slices"
+	"strconv"
+)
 
+// Sub returns the value of the given path in the values.
+// The numeric key in the path indexes into []any,
+// and it returns nil if the index is out of range.
 func Sub(values map[string]any, path []string) any {
+	value, _ := Lookup(values, path)
+
+	return value
+}
+
+// Lookup works like Sub, but also returns whether the path exists,
+// which distinguishes the missing path from the path with nil value.
+func Lookup(values map[string]any, path []string) (any, bool) {
 	path = slices.Compact(path)
 	if len(path) == 0 {
-		return values
+		return values, true
 	}
 
-	_, value := Unpack(values[path[0]])
-	if len(path) == 1 {
-		return value
-	}
+	return lookup(values, path)
+}
 
-	if mp, ok := value.(map[string]any); ok {
-		return Sub(mp, path[1:])
+func lookup(value any, path []string) (any, bool) {
+	if len(path) == 0 {
+		return value, true
 	}
 
-	return nil
+	switch value := value.(type) {
+	case map[string]any:
+		val, ok := value[path[0]]
+		if !ok {
+			return nil, false
+		}
+		_, val = Unpack(val)
+
+		return lookup(val, path[1:])
+	case []any:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(value) {
+			return nil, false
+		}
+		_, val := Unpack(value[index])
+
+		return lookup(val, path[1:])
+	default:
+		return nil, false
+	}
 }
@@ -77,6 +77,24 @@ func TestSub(t *testing.T) {
 			path:        []string{"x", "y"},
 			expected:    nil,
 		},
+		{
+			description: "slice index",
+			values:      map[string]any{"a": []any{map[string]any{"x": 1}, map[string]any{"x": 2}}},
+			path:        []string{"a", "1", "x"},
+			expected:    2,
+		},
+		{
+			description: "slice index out of range",
+			values:      map[string]any{"a": []any{1}},
+			path:        []string{"a", "1"},
+			expected:    nil,
+		},
+		{
+			description: "non-numeric slice index",
+			values:      map[string]any{"a": []any{1}},
+			path:        []string{"a", "x"},
+			expected:    nil,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -88,3 +106,19 @@ func TestSub(t *testing.T) {
 		})
 	}
 }
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]any{"a": map[string]any{"x": nil}, "b": []any{nil}}
+	for _, path := range [][]string{{"a", "x"}, {"b", "0"}} {
+		value, ok := maps.Lookup(values, path)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, nil, value)
+	}
+	for _, path := range [][]string{{"a", "y"}, {"b", "1"}, {"c"}} {
+		value, ok := maps.Lookup(values, path)
+		assert.Equal(t, false, ok)
+		assert.Equal(t, nil, value)
+	}
+}
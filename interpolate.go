@@ -0,0 +1,159 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// interpolate returns a copy of the value with the `${path}` references in the string leaves
+// resolved against the configuration of the Config.
+func (c *Config) interpolate(value any) (any, error) {
+	root := c.root()
+	lookup := root.providers.sub
+	if c.lazyResolution {
+		lookup = root.providers.first
+	}
+	interpolator := &interpolator{
+		lookup: func(path string) any {
+			return lookup(root.splitPath(path))
+		},
+		expandEnv: c.expandEnv,
+	}
+
+	return interpolator.value(value)
+}
+
+type interpolator struct {
+	lookup    func(path string) any
+	expandEnv func(string) (string, error)
+	stack     []string // The references being resolved, for cycle detection.
+}
+
+func (i *interpolator) value(value any) (any, error) {
+	switch value := value.(type) {
+	case string:
+		return i.string(value)
+	case map[string]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			interpolated, err := i.value(val)
+			if err != nil {
+				return nil, err
+			}
+			values[key] = interpolated
+		}
+
+		return values, nil
+	case []any:
+		values := make([]any, len(value))
+		for index, val := range value {
+			interpolated, err := i.value(val)
+			if err != nil {
+				return nil, err
+			}
+			values[index] = interpolated
+		}
+
+		return values, nil
+	default:
+		return value, nil
+	}
+}
+
+func (i *interpolator) string(value string) (any, error) { //nolint:cyclop
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	// The value which is only a reference resolves to the referenced value as it is, e.g. a number or a map.
+	if strings.HasPrefix(value, "${") && strings.IndexByte(value, '}') == len(value)-1 {
+		resolved, found, err := i.resolve(value[2 : len(value)-1])
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return resolved, nil
+		}
+	}
+
+	// The environment variables are expanded only in the literal text and the unresolved references,
+	// since the resolved values have been expanded while resolving.
+	var builder, literal strings.Builder
+	builder.Grow(len(value))
+	flush := func() error {
+		text := literal.String()
+		literal.Reset()
+		if i.expandEnv != nil {
+			var err error
+			if text, err = i.expandEnv(text); err != nil {
+				return err
+			}
+		}
+		builder.WriteString(text)
+
+		return nil
+	}
+	for index := 0; index < len(value); index++ {
+		switch {
+		case strings.HasPrefix(value[index:], "$$"):
+			if i.expandEnv != nil {
+				literal.WriteString("$$") // Keep the escape for expanding environment variables.
+			} else {
+				literal.WriteByte('$')
+			}
+			index++
+		case strings.HasPrefix(value[index:], "${"):
+			end := strings.IndexByte(value[index+2:], '}')
+			if end < 0 {
+				literal.WriteString(value[index:])
+				index = len(value)
+
+				break
+			}
+			reference := value[index : index+3+end]
+			resolved, found, err := i.resolve(reference[2 : len(reference)-1])
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				builder.WriteString(fmt.Sprint(resolved))
+			} else {
+				literal.WriteString(reference)
+			}
+			index += len(reference) - 1
+		default:
+			literal.WriteByte(value[index])
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return builder.String(), nil
+}
+
+func (i *interpolator) resolve(path string) (any, bool, error) {
+	if index := slices.Index(i.stack, path); index >= 0 {
+		return nil, false, fmt.Errorf( //nolint:err113
+			"reference cycle: %s", strings.Join(append(slices.Clone(i.stack[index:]), path), " -> "),
+		)
+	}
+
+	value := i.lookup(path)
+	if value == nil {
+		return nil, false, nil
+	}
+
+	i.stack = append(i.stack, path)
+	defer func() { i.stack = i.stack[:len(i.stack)-1] }()
+	resolved, err := i.value(value)
+
+	return resolved, true, err
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestWithInterpolation(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		values      map[string]any
+		path        string
+		expected    any
+		err         string
+	}{
+		{
+			description: "references",
+			values:      map[string]any{"host": "localhost", "port": 8080, "url": "https://${host}:${port}"},
+			path:        "url",
+			expected:    "https://localhost:8080",
+		},
+		{
+			description: "nested references",
+			values: map[string]any{
+				"server": map[string]any{"host": "localhost", "addr": "${server.host}:80"},
+				"url":    "https://${server.addr}",
+			},
+			path:     "url",
+			expected: "https://localhost:80",
+		},
+		{
+			description: "only reference",
+			values:      map[string]any{"server": map[string]any{"port": 8080}, "port": "${server.port}"},
+			path:        "port",
+			expected:    8080,
+		},
+		{
+			description: "map",
+			values: map[string]any{
+				"host":   "localhost",
+				"server": map[string]any{"host": "${host}", "hosts": []any{"${host}", "remote"}},
+			},
+			path:     "server",
+			expected: map[string]any{"host": "localhost", "hosts": []any{"localhost", "remote"}},
+		},
+		{
+			description: "unresolved and escape",
+			values:      map[string]any{"value": "${unknown} costs $$5 ${host"},
+			path:        "value",
+			expected:    "${unknown} costs $5 ${host",
+		},
+		{
+			description: "cycle",
+			values:      map[string]any{"a": "${b}", "b": "x${c}", "c": "${a}", "value": "${a}"},
+			path:        "value",
+			err:         "interpolate: reference cycle: a -> b -> c -> a",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			config := konf.New(konf.WithInterpolation())
+			assert.NoError(t, config.Load(mapLoader(testcase.values)))
+
+			var value any
+			err := config.Unmarshal(testcase.path, &value)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, value)
+		})
+	}
+}
+
+func TestWithInterpolation_sub(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New(konf.WithInterpolation())
+	assert.NoError(t, config.Load(mapLoader{"host": "localhost", "server": map[string]any{"url": "http://${host}"}}))
+
+	var value string
+	assert.NoError(t, config.Sub("server").Unmarshal("url", &value))
+	assert.Equal(t, "http://localhost", value)
+}
+
+//nolint:paralleltest // It sets environment variables.
+func TestWithInterpolation_env(t *testing.T) {
+	t.Setenv("KONF_HOST", "env")
+	t.Setenv("KONF_PORT", "8080")
+
+	config := konf.New(konf.WithInterpolation(), konf.WithEnvExpand())
+	assert.NoError(t, config.Load(mapLoader{
+		"konf_host": "config",
+		"url":       "https://${KONF_HOST}:${KONF_PORT}/$$$KONF_PORT",
+	}))
+
+	var value string
+	assert.NoError(t, config.Unmarshal("url", &value))
+	assert.Equal(t, "https://config:8080/$8080", value)
+
+	// The resolved value is not expanded again.
+	config = konf.New(konf.WithInterpolation(), konf.WithEnvExpand())
+	assert.NoError(t, config.Load(mapLoader{
+		"password": "p$$KONF_PORT",
+		"dsn":      "${password}@${KONF_HOST}",
+	}))
+	assert.NoError(t, config.Unmarshal("password", &value))
+	assert.Equal(t, "p$KONF_PORT", value)
+	assert.NoError(t, config.Unmarshal("dsn", &value))
+	assert.Equal(t, "p$KONF_PORT@env", value)
+
+	config = konf.New(konf.WithInterpolation(), konf.WithStrictEnvExpand())
+	assert.NoError(t, config.Load(mapLoader{"url": "https://${KONF_UNKNOWN}"}))
+	assert.EqualError(t, config.Unmarshal("url", &value),
+		"interpolate: environment variable KONF_UNKNOWN is not set")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Lazy returns a Loader that defers creating the loader by the given factory and loading configuration from it
+// until the configuration is read from the Config for the first time (e.g. Config.Unmarshal),
+// which avoids the cost of the loaders that may not be needed.
+//
+// Since it's unknown which paths the loader owns before loading,
+// reading any path resolves all pending lazy loaders, including Config.Explain.
+// The error of the lazy loading is logged rather than returned,
+// and the configuration of the loader is treated as empty.
+//
+// If the created loader is a Watcher, it's watched after the loader is resolved.
+// If the created loader is a Statuser, the status is reported after the loader is resolved.
+func Lazy(factory func() (Loader, error)) Loader {
+	if factory == nil {
+		return nil
+	}
+
+	return &lazyLoader{factory: factory, resolved: make(chan struct{})}
+}
+
+type lazyLoader struct {
+	factory  func() (Loader, error)
+	onStatus func(changed bool, err error)
+
+	loader   Loader
+	err      error
+	once     sync.Once
+	resolved chan struct{}
+}
+
+func (l *lazyLoader) Load() (map[string]any, error) {
+	l.once.Do(func() {
+		defer close(l.resolved)
+
+		if l.loader, l.err = l.factory(); l.err != nil {
+			l.err = fmt.Errorf("create lazy loader: %w", l.err)
+
+			return
+		}
+		if statuser, ok := l.loader.(Statuser); ok && l.onStatus != nil {
+			statuser.Status(l.onStatus)
+		}
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	return l.loader.Load() //nolint:wrapcheck
+}
+
+func (l *lazyLoader) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-l.resolved:
+	}
+
+	if watcher, ok := l.loader.(Watcher); ok {
+		return watcher.Watch(ctx, onChange) //nolint:wrapcheck
+	}
+	<-ctx.Done() // Block until ctx is done as the watcher has nothing to watch.
+
+	return nil
+}
+
+func (l *lazyLoader) Status(onStatus func(changed bool, err error)) {
+	l.onStatus = onStatus
+}
+
+func (l *lazyLoader) String() string {
+	select {
+	case <-l.resolved:
+		if l.loader != nil {
+			return fmt.Sprintf("%v", l.loader)
+		}
+	default:
+	}
+
+	return "lazy"
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		read        func(*konf.Config) string
+		expected    string
+	}{
+		{
+			description: "unmarshal",
+			read: func(config *konf.Config) string {
+				var value string
+				assert.NoError(t, config.Unmarshal("host", &value))
+
+				return value
+			},
+			expected: "lazy",
+		},
+		{
+			description: "explain",
+			read: func(config *konf.Config) string {
+				return config.Explain("host")
+			},
+			expected: "host has value[lazy] that is loaded by loader[map].\nHere are other value(loader)s:\n  - default(map)\n\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var created atomic.Int32
+			config := konf.New()
+			assert.NoError(t, config.Load(mapLoader{"host": "default"}))
+			assert.NoError(t, config.Load(konf.Lazy(func() (konf.Loader, error) {
+				created.Add(1)
+
+				return mapLoader{"host": "lazy"}, nil
+			})))
+			assert.Equal(t, int32(0), created.Load())
+
+			assert.Equal(t, testcase.expected, testcase.read(config))
+			assert.Equal(t, testcase.read(config), testcase.read(config))
+			assert.Equal(t, int32(1), created.Load())
+		})
+	}
+}
+
+func TestLazy_error(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(konf.WithLogHandler(logHandler(buf)))
+	assert.NoError(t, config.Load(mapLoader{"host": "default"}))
+	assert.NoError(t, config.Load(konf.Lazy(func() (konf.Loader, error) {
+		return nil, errors.New("create error")
+	})))
+
+	var value string
+	assert.NoError(t, config.Unmarshal("host", &value))
+	assert.Equal(t, "default", value)
+	expected := `level=WARN msg="Error when loading configuration lazily." loader=lazy` +
+		` error="load configuration: create lazy loader: create error"` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestLazy_slow(t *testing.T) {
+	t.Parallel()
+
+	creating, release := make(chan struct{}), make(chan struct{})
+	config := konf.New()
+	assert.NoError(t, config.Load(konf.Lazy(func() (konf.Loader, error) {
+		close(creating)
+		<-release
+
+		return mapLoader{"host": "lazy"}, nil
+	})))
+
+	values := make(chan string, 2)
+	read := func() {
+		var value string
+		assert.NoError(t, config.Unmarshal("host", &value))
+		values <- value
+	}
+	go read()
+	<-creating
+	go read()
+
+	// The slow lazy loader does not block loading other loaders.
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+
+		assert.NoError(t, config.Load(mapLoader{"port": 8080}))
+	}()
+	select {
+	case <-loaded:
+	case <-time.After(time.Second):
+		t.Fatal("Config.Load is blocked by the lazy loader")
+	}
+
+	close(release)
+	assert.Equal(t, "lazy", <-values)
+	assert.Equal(t, "lazy", <-values)
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/nil-go/konf/internal/maps"
+)
+
+// MapLoader is a Loader that loads configuration from the in-memory map,
+// which could be mutated at runtime by MapLoader.Set and MapLoader.Delete,
+// e.g. injecting the dynamic values in integration tests or admin overrides.
+// The changes are pushed to the Config while it's watched.
+//
+// To create a new MapLoader, call [NewMapLoader].
+type MapLoader struct {
+	values    map[string]any
+	onChanges map[*func(map[string]any)]struct{}
+	mutex     sync.Mutex
+}
+
+// NewMapLoader creates an empty MapLoader.
+// It usually is loaded as the last loader, so its values take the highest precedence.
+func NewMapLoader() *MapLoader {
+	return &MapLoader{}
+}
+
+func (m *MapLoader) Load() (map[string]any, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.snapshot(), nil
+}
+
+func (m *MapLoader) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	m.mutex.Lock()
+	if m.onChanges == nil { // To support zero MapLoader
+		m.onChanges = make(map[*func(map[string]any)]struct{})
+	}
+	m.onChanges[&onChange] = struct{}{}
+	m.mutex.Unlock()
+
+	<-ctx.Done()
+
+	m.mutex.Lock()
+	delete(m.onChanges, &onChange)
+	m.mutex.Unlock()
+
+	return nil
+}
+
+// Set sets the value of the given path, which is split by the default delimiter ".".
+// It replaces the existing value of the path, including the nested map.
+func (m *MapLoader) Set(path string, value any) {
+	if path == "" {
+		return
+	}
+
+	m.update(func(values map[string]any) {
+		maps.Insert(values, strings.Split(path, "."), value)
+	})
+}
+
+// Delete deletes the value of the given path, which is split by the default delimiter ".".
+func (m *MapLoader) Delete(path string) {
+	if path == "" {
+		return
+	}
+
+	m.update(func(values map[string]any) {
+		keys := strings.Split(path, ".")
+		for _, key := range keys[:len(keys)-1] {
+			next, ok := values[key].(map[string]any)
+			if !ok {
+				return
+			}
+			values = next
+		}
+		delete(values, keys[len(keys)-1])
+	})
+}
+
+func (m *MapLoader) update(action func(map[string]any)) {
+	m.mutex.Lock()
+	if m.values == nil { // To support zero MapLoader
+		m.values = make(map[string]any)
+	}
+	action(m.values)
+	notifies := make([]func(), 0, len(m.onChanges))
+	for onChange := range m.onChanges {
+		// Each watcher receives its own copy since the Config may modify it.
+		values := m.snapshot()
+		notifies = append(notifies, func() { (*onChange)(values) })
+	}
+	m.mutex.Unlock()
+
+	for _, notify := range notifies {
+		notify()
+	}
+}
+
+// snapshot returns the deep copy of the values. It must be called with the lock.
+func (m *MapLoader) snapshot() map[string]any {
+	values := make(map[string]any)
+	maps.Merge(values, m.values)
+
+	return values
+}
+
+func (m *MapLoader) String() string {
+	return "map"
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestMapLoader(t *testing.T) {
+	t.Parallel()
+
+	loader := konf.NewMapLoader()
+	loader.Set("server.host", "localhost")
+	loader.Set("server.port", 8080)
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"server": map[string]any{"host": "example.com", "timeout": "1s"}}))
+	assert.NoError(t, config.Load(loader))
+
+	var server map[string]any
+	assert.NoError(t, config.Unmarshal("server", &server))
+	assert.Equal(t, map[string]any{"host": "localhost", "port": 8080, "timeout": "1s"}, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { changed <- struct{}{} }, "server")
+	loader.Set("server.host", "example.org")
+	<-changed
+	var host string
+	assert.NoError(t, config.Unmarshal("server.host", &host))
+	assert.Equal(t, "example.org", host)
+
+	loader.Delete("server.host")
+	<-changed
+	assert.NoError(t, config.Unmarshal("server.host", &host))
+	assert.Equal(t, "example.com", host)
+}
+
+func TestMapLoader_zero(t *testing.T) {
+	t.Parallel()
+
+	var loader konf.MapLoader
+	loader.Set("a.b", "c")
+	loader.Delete("a.x.y")
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": "c"}}, values)
+	assert.Equal(t, "map", loader.String())
+}
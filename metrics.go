@@ -0,0 +1,26 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import "time"
+
+// MetricsRecorder is the interface for recording metrics of configuration loading/watching.
+// The loader is identified by its string representation.
+//
+// It can be adapted to the metrics system (e.g. Prometheus, OpenTelemetry) of the application.
+// The methods must be concurrent-safe and non-blocking.
+type MetricsRecorder interface {
+	// LoadDuration records how long loading configuration from the loader took.
+	LoadDuration(loader string, duration time.Duration)
+	// LoadError records an error when loading/watching configuration from the loader.
+	LoadError(loader string)
+	// Changed records a change of configuration from the loader.
+	Changed(loader string)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) LoadDuration(string, time.Duration) {}
+func (nopMetrics) LoadError(string)                   {}
+func (nopMetrics) Changed(string)                     {}
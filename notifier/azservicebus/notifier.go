@@ -24,6 +24,8 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 	"github.com/google/uuid"
+
+	"github.com/nil-go/konf/notifier/azservicebus/internal/credential"
 )
 
 // Notifier that watches change events on given Service Bus topic.
@@ -34,6 +36,7 @@ type Notifier struct {
 	topic      string
 	credential azcore.TokenCredential
 	logger     *slog.Logger
+	filter     func(messaging.CloudEvent) bool
 
 	loaders      []loader
 	loadersMutex sync.RWMutex
@@ -105,7 +108,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 		); derr != nil {
 			logger.LogAttrs(ctx, slog.LevelWarn,
 				"Fail to delete service bus subscription.",
-				slog.String("topic", n.topic),
+				slog.String("topic", credential.Blur("topic", n.topic)),
 				slog.String("subscription", subscriptionName),
 				slog.Any("error", derr),
 			)
@@ -124,7 +127,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 	}
 	logger.LogAttrs(ctx, slog.LevelInfo,
 		"Start watching service bus topic.",
-		slog.String("topic", n.topic),
+		slog.String("topic", credential.Blur("topic", n.topic)),
 		slog.String("subscription", subscriptionName),
 	)
 
@@ -157,7 +160,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 
 			logger.LogAttrs(ctx, slog.LevelInfo,
 				"Received messages from service bus topic.",
-				slog.String("topic", n.topic),
+				slog.String("topic", credential.Blur("topic", n.topic)),
 				slog.Int("count", len(messages)),
 			)
 
@@ -178,6 +181,10 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 
 					continue
 				}
+				if n.filter != nil && !n.filter(event) {
+					// The message has been deleted from the subscription while receiving.
+					continue
+				}
 
 				var errM error
 				for _, loader := range loaders {
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/messaging"
 )
 
 // WithCredential provides the azcore.TokenCredential for Azure authentication.
@@ -18,6 +19,16 @@ func WithCredential(credential azcore.TokenCredential) Option {
 	}
 }
 
+// WithFilter provides the function to filter the events before fanout to registered loaders.
+// The events that the filter returns false are dropped.
+//
+// By default, all events are fanned out to registered loaders.
+func WithFilter(filter func(event messaging.CloudEvent) bool) Option {
+	return func(options *options) {
+		options.filter = filter
+	}
+}
+
 // WithLogHandler provides the slog.Handler for logs from notifier.
 //
 // By default, it uses handler from slog.Default().
@@ -13,20 +13,26 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/compute/metadata"
 	"cloud.google.com/go/pubsub"
 	"github.com/google/uuid"
 	"google.golang.org/api/option"
+
+	"github.com/nil-go/konf/notifier/pubsub/internal/credential"
 )
 
 // Notifier that watches change events on given PubSub topic.
 //
 // To create a new Notifier, call [NewNotifier].
 type Notifier struct {
-	topic   string
-	project string
-	logger  *slog.Logger
+	topic       string
+	project     string
+	logger      *slog.Logger
+	ackDeadline time.Duration
+	ordering    bool
+	filter      func(map[string]string) bool
 
 	clientOpts   []option.ClientOption
 	loaders      []loader
@@ -100,7 +106,9 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen
 		}
 	}()
 	subscription, err := client.CreateSubscription(ctx, "konf-"+uuid.NewString(), pubsub.SubscriptionConfig{
-		Topic: client.Topic(n.topic),
+		Topic:                 client.Topic(n.topic),
+		AckDeadline:           n.ackDeadline,
+		EnableMessageOrdering: n.ordering,
 	})
 	if err != nil {
 		return fmt.Errorf("create PubSub subscription: %w", err)
@@ -109,7 +117,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen
 		if derr := subscription.Delete(context.WithoutCancel(ctx)); derr != nil {
 			logger.LogAttrs(ctx, slog.LevelWarn,
 				"Fail to delete pubsub subscription.",
-				slog.String("topic", n.topic),
+				slog.String("topic", credential.Blur("topic", n.topic)),
 				slog.String("subscription", subscription.String()),
 				slog.Any("error", derr),
 			)
@@ -117,15 +125,20 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen
 	}()
 	logger.LogAttrs(ctx, slog.LevelInfo,
 		"Start watching PubSub topic.",
-		slog.String("topic", n.topic),
+		slog.String("topic", credential.Blur("topic", n.topic)),
 		slog.String("subscription", subscription.String()),
 	)
 
 	err = subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
 		attributes := msg.Attributes
+		if n.filter != nil && !n.filter(attributes) {
+			msg.Ack() // Ack the dropped message so it's not redelivered.
+
+			return
+		}
 		logger.LogAttrs(ctx, slog.LevelInfo,
 			"Received PubSub message.",
-			slog.String("topic", n.topic),
+			slog.String("topic", credential.Blur("topic", n.topic)),
 			slog.Any("eventType", attributes["eventType"]),
 		)
 
@@ -149,7 +162,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen
 		if errors.Is(err, errors.ErrUnsupported) {
 			logger.LogAttrs(ctx, slog.LevelWarn,
 				"No loader to process message.",
-				slog.String("topic", n.topic),
+				slog.String("topic", credential.Blur("topic", n.topic)),
 				slog.Any("msg", msg.Attributes),
 			)
 		}
@@ -148,6 +148,118 @@ level=WARN msg="Fail to delete pubsub subscription." topic=topic subscription=pr
 	}
 }
 
+func TestNotifier_subscriptionConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reactor := &subscriptionReactor{}
+	srv := pstest.NewServer(pstest.ServerReactorOption{FuncName: "CreateSubscription", Reactor: reactor})
+	defer func() {
+		_ = srv.Close()
+	}()
+	topic := "projects/test/topics/topic"
+	_, err := srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: topic})
+	assert.NoError(t, err)
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	notifier := kpubsub.NewNotifier("topic",
+		kpubsub.WithProject("test"),
+		kpubsub.WithAckDeadline(time.Minute),
+		kpubsub.WithOrderingKey(true),
+		kpubsub.WithLogHandler(logHandler(&buffer{})),
+		option.WithGRPCConn(conn),
+	)
+	loader := &loader{cancel: cancel}
+	notifier.Register(loader)
+	var waitgroup sync.WaitGroup
+	waitgroup.Add(1)
+	go func() {
+		defer waitgroup.Done()
+		assert.NoError(t, notifier.Start(ctx))
+	}()
+	time.Sleep(10 * time.Millisecond) // Wait for notifier starts.
+	srv.Publish(topic, []byte{}, map[string]string{"eventType": "test"})
+	waitgroup.Wait()
+
+	assert.Equal(t, true, loader.notified.Load())
+	subscription := reactor.subscription.Load()
+	assert.Equal(t, true, regexp.MustCompile(`^projects/test/subscriptions/konf-[0-9a-f-]+$`).MatchString(subscription.GetName()))
+	assert.Equal(t, int32(60), subscription.GetAckDeadlineSeconds())
+	assert.Equal(t, true, subscription.GetEnableMessageOrdering())
+	subscriptions, err := srv.GServer.ListSubscriptions(ctx, &pubsubpb.ListSubscriptionsRequest{Project: "projects/test"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(subscriptions.GetSubscriptions()))
+}
+
+func TestNotifier_filter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv := pstest.NewServer()
+	defer func() {
+		_ = srv.Close()
+	}()
+	topic := "projects/test/topics/topic"
+	_, err := srv.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: topic})
+	assert.NoError(t, err)
+
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	buf := &buffer{}
+	notifier := kpubsub.NewNotifier("topic",
+		kpubsub.WithProject("test"),
+		kpubsub.WithFilter(func(attributes map[string]string) bool {
+			return attributes["eventType"] == "test"
+		}),
+		kpubsub.WithLogHandler(logHandler(buf)),
+		option.WithGRPCConn(conn),
+	)
+	loader := &loader{cancel: cancel}
+	notifier.Register(loader)
+	var waitgroup sync.WaitGroup
+	waitgroup.Add(1)
+	go func() {
+		defer waitgroup.Done()
+		assert.NoError(t, notifier.Start(ctx))
+	}()
+	time.Sleep(10 * time.Millisecond) // Wait for notifier starts.
+	dropped := srv.Publish(topic, []byte{}, map[string]string{"eventType": "other"})
+	time.Sleep(10 * time.Millisecond) // Wait for the dropped message is acknowledged.
+	srv.Publish(topic, []byte{}, map[string]string{"eventType": "test"})
+	waitgroup.Wait()
+
+	assert.Equal(t, true, loader.notified.Load())
+	assert.Equal(t, 1, srv.Message(dropped).Acks)
+	expected := `level=INFO msg="Start watching PubSub topic." topic=topic subscription=projects/test/subscriptions/konf-
+level=INFO msg="Received PubSub message." topic=topic eventType=test
+`
+	re := regexp.MustCompile(`konf-[0-9a-f-]+`)
+	assert.Equal(t, expected, re.ReplaceAllString(buf.String(), "konf-"))
+}
+
+type subscriptionReactor struct {
+	subscription atomic.Pointer[pubsubpb.Subscription]
+}
+
+func (r *subscriptionReactor) React(req any) (bool, any, error) {
+	if subscription, ok := req.(*pubsubpb.Subscription); ok {
+		r.subscription.Store(subscription)
+	}
+
+	return false, nil, nil
+}
+
 type loader struct {
 	notified atomic.Bool
 	cancel   context.CancelFunc
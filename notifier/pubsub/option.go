@@ -6,6 +6,7 @@ package pubsub
 
 import (
 	"log/slog"
+	"time"
 
 	"google.golang.org/api/option"
 	"google.golang.org/api/option/internaloption"
@@ -22,6 +23,44 @@ func WithProject(project string) Option {
 	}
 }
 
+// WithAckDeadline provides the ack deadline of the subscription,
+// which is the maximum time after receiving a message before it's redelivered.
+// It must be between 10 seconds and 10 minutes.
+//
+// By default, it uses 10 seconds.
+func WithAckDeadline(deadline time.Duration) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.ackDeadline = deadline
+		},
+	}
+}
+
+// WithOrderingKey enables the message ordering of the subscription,
+// so the messages with the same ordering key are received in the order they were published,
+// e.g. adding and then disabling secret versions.
+//
+// By default, the messages are received without ordering.
+func WithOrderingKey(ordering bool) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.ordering = ordering
+		},
+	}
+}
+
+// WithFilter provides the function to filter the message attributes before fanout to registered loaders.
+// The messages that the filter returns false are acknowledged and dropped.
+//
+// By default, all messages are fanned out to registered loaders.
+func WithFilter(filter func(attributes map[string]string) bool) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.filter = filter
+		},
+	}
+}
+
 // WithLogHandler provides the slog.Handler for logs from notifier.
 //
 // By default, it uses handler from slog.Default().
@@ -1,7 +1,7 @@
 // Copyright (c) 2024 The konf authors
 // Use of this source code is governed by a MIT license found in the LICENSE file.
 
-// Package sns provides a notifier that subscribes to a SNS topic that watches change of configuration on AWS.
+// Package sns provides a notifier that subscribes to SNS topics that watches change of configuration on AWS.
 //
 // It [Fanout SNS topic to Amazon SQS queues], which requires following permissions:
 //   - sns:Subscribe
@@ -14,11 +14,14 @@ package sns
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"reflect"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,16 +33,27 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go/rand"
+
+	"github.com/nil-go/konf/notifier/sns/internal/credential"
 )
 
-// Notifier that watches change events on given SNS topic.
+// Notifier that watches change events on given SNS topics.
 //
 // To create a new Notifier, call [NewNotifier].
 type Notifier struct {
-	topic  string
+	topics []string
 	config aws.Config
 	logger *slog.Logger
 
+	maxMessages       int32
+	waitTime          int32
+	retryInterval     time.Duration
+	visibilityTimeout time.Duration
+	deadLetterQueue   string
+	maxReceiveCount   int
+	filter            func([]byte) bool
+	err               error // The error of invalid options, which is returned by Start.
+
 	loaders      []loader
 	loadersMutex sync.RWMutex
 }
@@ -47,9 +61,13 @@ type Notifier struct {
 type loader interface{ OnEvent([]byte) error }
 
 // NewNotifier creates a Notifier with the given SNS topic Name or ARN.
+// More topics can be added with WithTopics.
 func NewNotifier(topic string, opts ...Option) *Notifier {
 	option := &options{
-		topic: topic,
+		topics:        []string{topic},
+		maxMessages:   10,               //nolint:mnd // The maximum number of messages SQS returns.
+		waitTime:      20,               //nolint:mnd // The maximum wait time of SQS long polling.
+		retryInterval: 20 * time.Second, //nolint:mnd
 	}
 	for _, opt := range opts {
 		opt(option)
@@ -71,12 +89,16 @@ func (n *Notifier) Register(loaders ...loader) {
 
 var errNil = errors.New("nil Notifier")
 
-// Start starts watching events on given SNS topic and fanout to registered loaders.
+// Start starts watching events on given SNS topics and fanout to registered loaders.
+// It subscribes a single SQS queue to all topics.
 // It blocks until ctx is done, or it returns an error.
 func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,gocognit,maintidx
 	if n == nil {
 		return errNil
 	}
+	if n.err != nil {
+		return fmt.Errorf("invalid options: %w", n.err)
+	}
 
 	logger := n.logger
 	if n.logger == nil {
@@ -91,16 +113,28 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 	}
 
 	snsClient := sns.NewFromConfig(n.config)
-	topicArn := n.topic
-	if !arn.IsARN(topicArn) {
-		// Here uses CreateTopic to get topic ARN as the topic already exists.
-		topic, err := snsClient.CreateTopic(ctx, &sns.CreateTopicInput{
-			Name: aws.String(n.topic),
-		})
-		if err != nil {
-			return fmt.Errorf("get sns topic ARN: %w", err)
+	topicArns := make([]string, 0, len(n.topics))
+	blurredTopics := make([]string, 0, len(n.topics))
+	for _, topicArn := range n.topics {
+		if !arn.IsARN(topicArn) {
+			// Here uses CreateTopic to get topic ARN as the topic already exists.
+			topic, err := snsClient.CreateTopic(ctx, &sns.CreateTopicInput{
+				Name: aws.String(topicArn),
+			})
+			if err != nil {
+				return fmt.Errorf("get sns topic ARN: %w", err)
+			}
+			topicArn = *topic.TopicArn
 		}
-		topicArn = *topic.TopicArn
+		topicArns = append(topicArns, topicArn)
+	}
+	for _, topic := range n.topics {
+		blurredTopics = append(blurredTopics, credential.Blur("topic", topic))
+	}
+	topics := strings.Join(blurredTopics, ",")
+	sourceArns, err := json.Marshal(topicArns)
+	if err != nil {
+		return fmt.Errorf("marshal sns topic ARNs: %w", err)
 	}
 
 	stsClient := sts.NewFromConfig(n.config)
@@ -121,7 +155,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 			"Resource":"*",
 			"Condition":{
 				"ArnEquals":{
-					"aws:SourceArn":"%s"
+					"aws:SourceArn":%s
 				}
 			}
 		},
@@ -139,18 +173,29 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 			"Resource":"*"
 		}
 	]
-}`, topicArn, aws.ToString(identity.Arn))
+}`, sourceArns, aws.ToString(identity.Arn))
 
 	sqsClient := sqs.NewFromConfig(n.config)
 	uuid, err := rand.NewUUID(rand.Reader).GetUUID()
 	if err != nil {
 		return fmt.Errorf("generate uuid: %w", err)
 	}
+	attributes := map[string]string{
+		"Policy": policy,
+	}
+	if n.deadLetterQueue != "" {
+		redrivePolicy, err := json.Marshal(map[string]string{
+			"deadLetterTargetArn": n.deadLetterQueue,
+			"maxReceiveCount":     strconv.Itoa(n.maxReceiveCount),
+		})
+		if err != nil {
+			return fmt.Errorf("marshal sqs redrive policy: %w", err)
+		}
+		attributes["RedrivePolicy"] = string(redrivePolicy)
+	}
 	queue, err := sqsClient.CreateQueue(ctx, &sqs.CreateQueueInput{
-		QueueName: aws.String("konf-" + uuid),
-		Attributes: map[string]string{
-			"Policy": policy,
-		},
+		QueueName:  aws.String("konf-" + uuid),
+		Attributes: attributes,
 	})
 	if err != nil {
 		return fmt.Errorf("create sqs queue: %w", err)
@@ -161,7 +206,7 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 		}); derr != nil {
 			logger.LogAttrs(ctx, slog.LevelWarn,
 				"Fail to delete sqs queue.",
-				slog.String("queue", *queue.QueueUrl),
+				slog.String("queue", credential.Blur("queue", *queue.QueueUrl)),
 				slog.Any("error", derr),
 			)
 		}
@@ -175,31 +220,41 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 	}
 	queueArn := queueAttrs.Attributes["QueueArn"]
 
-	Subscription, err := snsClient.Subscribe(ctx, &sns.SubscribeInput{
-		TopicArn:              aws.String(topicArn),
-		Protocol:              aws.String("sqs"),
-		Endpoint:              aws.String(queueArn),
-		Attributes:            map[string]string{"RawMessageDelivery": "true"},
-		ReturnSubscriptionArn: true,
-	})
-	if err != nil {
-		return fmt.Errorf("subscribe sns topic %s: %w", n.topic, err)
-	}
+	subscriptions := make([]*string, len(topicArns))
 	defer func() {
-		if _, derr := snsClient.Unsubscribe(context.WithoutCancel(ctx), &sns.UnsubscribeInput{
-			SubscriptionArn: Subscription.SubscriptionArn,
-		}); derr != nil {
-			logger.LogAttrs(ctx, slog.LevelWarn,
-				"Fail to unsubscribe sns topic.",
-				slog.String("topic", n.topic),
-				slog.Any("error", derr),
-			)
+		// Unsubscribe all the subscriptions, including those before a failed subscribe.
+		for i, subscription := range subscriptions {
+			if subscription == nil {
+				continue
+			}
+			if _, derr := snsClient.Unsubscribe(context.WithoutCancel(ctx), &sns.UnsubscribeInput{
+				SubscriptionArn: subscription,
+			}); derr != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn,
+					"Fail to unsubscribe sns topic.",
+					slog.String("topic", blurredTopics[i]),
+					slog.Any("error", derr),
+				)
+			}
 		}
 	}()
+	for i, topicArn := range topicArns {
+		subscription, err := snsClient.Subscribe(ctx, &sns.SubscribeInput{
+			TopicArn:              aws.String(topicArn),
+			Protocol:              aws.String("sqs"),
+			Endpoint:              aws.String(queueArn),
+			Attributes:            map[string]string{"RawMessageDelivery": "true"},
+			ReturnSubscriptionArn: true,
+		})
+		if err != nil {
+			return fmt.Errorf("subscribe sns topic %s: %w", n.topics[i], err)
+		}
+		subscriptions[i] = subscription.SubscriptionArn
+	}
 	logger.LogAttrs(ctx, slog.LevelInfo,
 		"Start watching SNS topic.",
-		slog.String("topic", n.topic),
-		slog.String("queue", *queue.QueueUrl),
+		slog.String("topic", topics),
+		slog.String("queue", credential.Blur("queue", *queue.QueueUrl)),
 	)
 
 	timer := time.NewTimer(0)
@@ -212,18 +267,19 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 		case <-timer.C:
 			messages, err := sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
 				QueueUrl:            queue.QueueUrl,
-				MaxNumberOfMessages: 10, //nolint:mnd // The maximum number of messages to return.
-				WaitTimeSeconds:     20, //nolint:mnd // The maximum amount of time for waiting messages.
+				MaxNumberOfMessages: n.maxMessages,
+				WaitTimeSeconds:     n.waitTime,
+				VisibilityTimeout:   int32(n.visibilityTimeout / time.Second),
 			})
 			if err != nil {
 				if !errors.Is(err, context.Canceled) {
 					logger.LogAttrs(ctx, slog.LevelWarn,
 						"Fail to receive sqs message.",
-						slog.String("queue", *queue.QueueUrl),
+						slog.String("queue", credential.Blur("queue", *queue.QueueUrl)),
 						slog.Any("error", err),
 					)
 				}
-				timer.Reset(20 * time.Second) //nolint:mnd // Retry after 20 seconds to avoid busy loop.
+				timer.Reset(n.retryInterval) // Retry after interval to avoid busy loop.
 
 				continue
 			}
@@ -235,16 +291,33 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 
 			logger.LogAttrs(ctx, slog.LevelInfo,
 				"Received messages from SNS topic.",
-				slog.String("topic", n.topic),
+				slog.String("topic", topics),
 				slog.Int("count", len(messages.Messages)),
 			)
 
+			var extended sync.WaitGroup
+			processed := make(chan struct{})
+			if n.visibilityTimeout > 0 {
+				extended.Add(1)
+				go func() {
+					defer extended.Done()
+					n.extendVisibility(ctx, sqsClient, queue.QueueUrl, messages.Messages, processed, logger)
+				}()
+			}
+
 			n.loadersMutex.RLock()
 			loaders := slices.Clone(n.loaders)
 			n.loadersMutex.RUnlock()
+			entries := make([]types.DeleteMessageBatchRequestEntry, 0, len(messages.Messages))
 			for _, msg := range messages.Messages {
 				bytes := []byte(*msg.Body)
-				if len(bytes) == 0 {
+				if len(bytes) == 0 || n.filter != nil && !n.filter(bytes) {
+					// Delete the empty or dropped message so it's not redelivered.
+					entries = append(entries, types.DeleteMessageBatchRequestEntry{
+						Id:            msg.MessageId,
+						ReceiptHandle: msg.ReceiptHandle,
+					})
+
 					continue
 				}
 
@@ -272,22 +345,72 @@ func (n *Notifier) Start(ctx context.Context) error { //nolint:cyclop,funlen,goc
 						slog.String("msg", *msg.Body),
 					)
 				}
-			}
+				if errM != nil && !errors.Is(errM, errors.ErrUnsupported) {
+					// Leave the failed message in the queue for redelivery,
+					// so it can flow to the dead-letter queue of WithDeadLetterQueue after repeated failures.
+					continue
+				}
 
-			entries := make([]types.DeleteMessageBatchRequestEntry, 0, len(messages.Messages))
-			for _, msg := range messages.Messages {
 				entries = append(entries, types.DeleteMessageBatchRequestEntry{
 					Id:            msg.MessageId,
 					ReceiptHandle: msg.ReceiptHandle,
 				})
 			}
+			close(processed)
+			extended.Wait()
+
+			if len(entries) == 0 {
+				continue
+			}
 			if _, err = sqsClient.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
 				QueueUrl: queue.QueueUrl,
 				Entries:  entries,
 			}); err != nil && !errors.Is(err, context.Canceled) {
 				logger.LogAttrs(ctx, slog.LevelWarn,
 					"Fail to delete sqs message.",
-					slog.String("queue", *queue.QueueUrl),
+					slog.String("queue", credential.Blur("queue", *queue.QueueUrl)),
+					slog.Any("error", err),
+				)
+			}
+		}
+	}
+}
+
+// extendVisibility extends the visibility timeout of the in-flight messages periodically
+// until they have been processed, so that they are not redelivered while loaders are processing.
+func (n *Notifier) extendVisibility(
+	ctx context.Context,
+	client *sqs.Client,
+	queueURL *string,
+	messages []types.Message,
+	processed <-chan struct{},
+	logger *slog.Logger,
+) {
+	ticker := time.NewTicker(n.visibilityTimeout / 2) //nolint:mnd // Extend before the timeout.
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-processed:
+			return
+		case <-ticker.C:
+			entries := make([]types.ChangeMessageVisibilityBatchRequestEntry, 0, len(messages))
+			for _, msg := range messages {
+				entries = append(entries, types.ChangeMessageVisibilityBatchRequestEntry{
+					Id:                msg.MessageId,
+					ReceiptHandle:     msg.ReceiptHandle,
+					VisibilityTimeout: int32(n.visibilityTimeout / time.Second),
+				})
+			}
+			if _, err := client.ChangeMessageVisibilityBatch(ctx, &sqs.ChangeMessageVisibilityBatchInput{
+				QueueUrl: queueURL,
+				Entries:  entries,
+			}); err != nil && !errors.Is(err, context.Canceled) {
+				logger.LogAttrs(ctx, slog.LevelWarn,
+					"Fail to extend sqs message visibility.",
+					slog.String("queue", credential.Blur("queue", *queueURL)),
 					slog.Any("error", err),
 				)
 			}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -122,6 +123,79 @@ func TestNotifier(t *testing.T) {
 			},
 			notified: true,
 		},
+		{
+			description: "blur queue",
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetCallerIdentity":
+					return middleware.FinalizeOutput{
+						Result: &sts.GetCallerIdentityOutput{
+							Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+						},
+					}, middleware.Metadata{}, nil
+				case "CreateTopic":
+					return middleware.FinalizeOutput{
+						Result: &sns.CreateTopicOutput{
+							TopicArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic"),
+						},
+					}, middleware.Metadata{}, nil
+				case "CreateQueue":
+					return middleware.FinalizeOutput{
+						Result: &sqs.CreateQueueOutput{
+							QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue?sig=abcdefghijklmnopqrstuvwxyz0123"),
+						},
+					}, middleware.Metadata{}, nil
+				case "DeleteQueue":
+					return middleware.FinalizeOutput{
+						Result: &sqs.DeleteQueueOutput{},
+					}, middleware.Metadata{}, nil
+				case "GetQueueAttributes":
+					return middleware.FinalizeOutput{
+						Result: &sqs.GetQueueAttributesOutput{
+							Attributes: map[string]string{
+								"QueueArn": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+							},
+						},
+					}, middleware.Metadata{}, nil
+				case "Subscribe":
+					return middleware.FinalizeOutput{
+						Result: &sns.SubscribeOutput{
+							SubscriptionArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic:12345678901234567890123456789012"),
+						},
+					}, middleware.Metadata{}, nil
+				case "Unsubscribe":
+					return middleware.FinalizeOutput{
+						Result: &sns.UnsubscribeOutput{},
+					}, middleware.Metadata{}, nil
+				case "ReceiveMessage":
+					return middleware.FinalizeOutput{
+						Result: &sqs.ReceiveMessageOutput{
+							Messages: []types.Message{
+								{
+									MessageId:     aws.String("message-id"),
+									ReceiptHandle: aws.String("receipt-handle"),
+									Body:          aws.String("message"),
+								},
+							},
+						},
+					}, middleware.Metadata{}, nil
+				case "DeleteMessageBatch":
+					return middleware.FinalizeOutput{
+						Result: &sqs.DeleteMessageBatchOutput{},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			notified: true,
+			log: `level=INFO msg="Start watching SNS topic." topic=topic queue="Azure SAS Token"
+level=INFO msg="Received messages from SNS topic." topic=topic count=1
+`,
+		},
 		{
 			description: "empty message",
 			middleware: func(
@@ -820,14 +894,573 @@ level=WARN msg="Fail to delete sqs message." queue=https://sqs.us-west-2.amazona
 	}
 }
 
+func TestNotifier_topics(t *testing.T) {
+	t.Parallel()
+
+	var (
+		policy        string
+		subscribed    []string
+		unsubscribed  []string
+		subscribeLock sync.Mutex
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(
+					middleware.InitializeMiddlewareFunc(
+						"mock",
+						func(
+							_ context.Context,
+							input middleware.InitializeInput,
+							_ middleware.InitializeHandler,
+						) (middleware.InitializeOutput, middleware.Metadata, error) {
+							subscribeLock.Lock()
+							defer subscribeLock.Unlock()
+
+							switch params := input.Parameters.(type) {
+							case *sts.GetCallerIdentityInput:
+								return middleware.InitializeOutput{
+									Result: &sts.GetCallerIdentityOutput{
+										Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.CreateTopicInput:
+								return middleware.InitializeOutput{
+									Result: &sns.CreateTopicOutput{
+										TopicArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.CreateQueueInput:
+								policy = params.Attributes["Policy"]
+
+								return middleware.InitializeOutput{
+									Result: &sqs.CreateQueueOutput{
+										QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.GetQueueAttributesInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.GetQueueAttributesOutput{
+										Attributes: map[string]string{
+											"QueueArn": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.SubscribeInput:
+								topicArn := *params.TopicArn
+								subscribed = append(subscribed, topicArn)
+
+								return middleware.InitializeOutput{
+									Result: &sns.SubscribeOutput{
+										SubscriptionArn: aws.String(topicArn + ":subscription"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.UnsubscribeInput:
+								unsubscribed = append(unsubscribed, *params.SubscriptionArn)
+
+								return middleware.InitializeOutput{
+									Result: &sns.UnsubscribeOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.ReceiveMessageInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.ReceiveMessageOutput{
+										Messages: []types.Message{
+											{
+												MessageId:     aws.String("message-id"),
+												ReceiptHandle: aws.String("receipt-handle"),
+												Body:          aws.String("message"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteMessageBatchInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteMessageBatchOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteQueueInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteQueueOutput{},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	notifier := ksns.NewNotifier("topic",
+		ksns.WithAWSConfig(cfg),
+		ksns.WithTopics("arn:aws:sns:us-west-2:123456789012:OtherTopic"),
+	)
+	loader := &loader{cancel: cancel}
+	notifier.Register(loader)
+	assert.NoError(t, notifier.Start(ctx))
+
+	assert.Equal(t, true, loader.notified.Load())
+	assert.Equal(t, true, strings.Contains(policy,
+		`"aws:SourceArn":["arn:aws:sns:us-west-2:123456789012:MyTopic","arn:aws:sns:us-west-2:123456789012:OtherTopic"]`,
+	))
+	assert.Equal(t,
+		[]string{"arn:aws:sns:us-west-2:123456789012:MyTopic", "arn:aws:sns:us-west-2:123456789012:OtherTopic"},
+		subscribed,
+	)
+	assert.Equal(t,
+		[]string{
+			"arn:aws:sns:us-west-2:123456789012:MyTopic:subscription",
+			"arn:aws:sns:us-west-2:123456789012:OtherTopic:subscription",
+		},
+		unsubscribed,
+	)
+}
+
+func TestNotifier_invalidOptions(t *testing.T) {
+	t.Parallel()
+
+	notifier := ksns.NewNotifier("topic", ksns.WithReceiveOptions(100, -1))
+	assert.EqualError(t, notifier.Start(context.Background()), "invalid options: "+
+		"max number of messages 100 is out of range [1, 10]\nwait time -1 seconds is out of range [0, 20]")
+}
+
+func TestNotifier_deadLetterQueue(t *testing.T) {
+	t.Parallel()
+
+	var attributes map[string]string
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(
+					middleware.InitializeMiddlewareFunc(
+						"mock",
+						func(
+							_ context.Context,
+							input middleware.InitializeInput,
+							_ middleware.InitializeHandler,
+						) (middleware.InitializeOutput, middleware.Metadata, error) {
+							switch params := input.Parameters.(type) {
+							case *sts.GetCallerIdentityInput:
+								return middleware.InitializeOutput{
+									Result: &sts.GetCallerIdentityOutput{
+										Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.CreateQueueInput:
+								attributes = params.Attributes
+
+								return middleware.InitializeOutput{}, middleware.Metadata{}, errors.New("create queue error")
+							default:
+								return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	notifier := ksns.NewNotifier("arn:aws:sns:us-west-2:123456789012:MyTopic",
+		ksns.WithAWSConfig(cfg),
+		ksns.WithDeadLetterQueue("arn:aws:sqs:us-west-2:123456789012:DLQ", 5),
+	)
+	assert.EqualError(t, notifier.Start(context.Background()),
+		"create sqs queue: operation error SQS: CreateQueue, create queue error")
+	assert.Equal(t, `{"deadLetterTargetArn":"arn:aws:sqs:us-west-2:123456789012:DLQ","maxReceiveCount":"5"}`,
+		attributes["RedrivePolicy"])
+
+	notifier = ksns.NewNotifier("topic", ksns.WithDeadLetterQueue("DLQ", 0))
+	assert.EqualError(t, notifier.Start(context.Background()), "invalid options: "+
+		"dead-letter queue DLQ is not an ARN\nmax receive count 0 is out of range [1, 1000]")
+}
+
+func TestNotifier_receiveOptions(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []ksns.Option
+		maxMessages int32
+		waitTime    int32
+	}{
+		{
+			description: "default",
+			maxMessages: 10,
+			waitTime:    20,
+		},
+		{
+			description: "with receive options",
+			opts:        []ksns.Option{ksns.WithReceiveOptions(1, 5)},
+			maxMessages: 1,
+			waitTime:    5,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				received  atomic.Int32
+				receiveIn atomic.Pointer[sqs.ReceiveMessageInput]
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cfg, err := config.LoadDefaultConfig(ctx,
+				config.WithAPIOptions([]func(*middleware.Stack) error{
+					func(stack *middleware.Stack) error {
+						return stack.Initialize.Add(
+							middleware.InitializeMiddlewareFunc(
+								"mock",
+								func(
+									_ context.Context,
+									input middleware.InitializeInput,
+									_ middleware.InitializeHandler,
+								) (middleware.InitializeOutput, middleware.Metadata, error) {
+									switch params := input.Parameters.(type) {
+									case *sts.GetCallerIdentityInput:
+										return middleware.InitializeOutput{
+											Result: &sts.GetCallerIdentityOutput{
+												Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+											},
+										}, middleware.Metadata{}, nil
+									case *sqs.CreateQueueInput:
+										return middleware.InitializeOutput{
+											Result: &sqs.CreateQueueOutput{
+												QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+											},
+										}, middleware.Metadata{}, nil
+									case *sqs.GetQueueAttributesInput:
+										return middleware.InitializeOutput{
+											Result: &sqs.GetQueueAttributesOutput{
+												Attributes: map[string]string{
+													"QueueArn": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+												},
+											},
+										}, middleware.Metadata{}, nil
+									case *sns.SubscribeInput:
+										return middleware.InitializeOutput{
+											Result: &sns.SubscribeOutput{
+												SubscriptionArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic:subscription"),
+											},
+										}, middleware.Metadata{}, nil
+									case *sns.UnsubscribeInput:
+										return middleware.InitializeOutput{
+											Result: &sns.UnsubscribeOutput{},
+										}, middleware.Metadata{}, nil
+									case *sqs.ReceiveMessageInput:
+										receiveIn.Store(params)
+										if received.Add(1) == 1 {
+											// Fail the first receive to verify the retry interval.
+											return middleware.InitializeOutput{}, middleware.Metadata{}, errors.New("receive message error")
+										}
+
+										return middleware.InitializeOutput{
+											Result: &sqs.ReceiveMessageOutput{
+												Messages: []types.Message{
+													{
+														MessageId:     aws.String("message-id"),
+														ReceiptHandle: aws.String("receipt-handle"),
+														Body:          aws.String("message"),
+													},
+												},
+											},
+										}, middleware.Metadata{}, nil
+									case *sqs.DeleteMessageBatchInput:
+										return middleware.InitializeOutput{
+											Result: &sqs.DeleteMessageBatchOutput{},
+										}, middleware.Metadata{}, nil
+									case *sqs.DeleteQueueInput:
+										return middleware.InitializeOutput{
+											Result: &sqs.DeleteQueueOutput{},
+										}, middleware.Metadata{}, nil
+									default:
+										return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+									}
+								},
+							),
+							middleware.Before,
+						)
+					},
+				}),
+			)
+			assert.NoError(t, err)
+
+			notifier := ksns.NewNotifier("arn:aws:sns:us-west-2:123456789012:MyTopic",
+				append(testcase.opts,
+					ksns.WithAWSConfig(cfg),
+					ksns.WithRetryInterval(10*time.Millisecond),
+					ksns.WithLogHandler(logHandler(&buffer{})),
+				)...,
+			)
+			loader := &loader{cancel: cancel}
+			notifier.Register(loader)
+			ctx, timeout := context.WithTimeout(ctx, time.Second)
+			defer timeout()
+			assert.NoError(t, notifier.Start(ctx))
+
+			assert.Equal(t, true, loader.notified.Load())
+			assert.Equal(t, testcase.maxMessages, receiveIn.Load().MaxNumberOfMessages)
+			assert.Equal(t, testcase.waitTime, receiveIn.Load().WaitTimeSeconds)
+		})
+	}
+}
+
+func TestNotifier_visibilityTimeout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		receiveTimeout atomic.Int32
+		extendTimeout  atomic.Int32
+		deleted        atomic.Pointer[[]string]
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(
+					middleware.InitializeMiddlewareFunc(
+						"mock",
+						func(
+							_ context.Context,
+							input middleware.InitializeInput,
+							_ middleware.InitializeHandler,
+						) (middleware.InitializeOutput, middleware.Metadata, error) {
+							switch params := input.Parameters.(type) {
+							case *sts.GetCallerIdentityInput:
+								return middleware.InitializeOutput{
+									Result: &sts.GetCallerIdentityOutput{
+										Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.CreateQueueInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.CreateQueueOutput{
+										QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.GetQueueAttributesInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.GetQueueAttributesOutput{
+										Attributes: map[string]string{
+											"QueueArn": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.SubscribeInput:
+								return middleware.InitializeOutput{
+									Result: &sns.SubscribeOutput{
+										SubscriptionArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic:subscription"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.UnsubscribeInput:
+								return middleware.InitializeOutput{
+									Result: &sns.UnsubscribeOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.ReceiveMessageInput:
+								receiveTimeout.Store(params.VisibilityTimeout)
+
+								return middleware.InitializeOutput{
+									Result: &sqs.ReceiveMessageOutput{
+										Messages: []types.Message{
+											{
+												MessageId:     aws.String("success"),
+												ReceiptHandle: aws.String("receipt-handle-success"),
+												Body:          aws.String("success"),
+											},
+											{
+												MessageId:     aws.String("failure"),
+												ReceiptHandle: aws.String("receipt-handle-failure"),
+												Body:          aws.String("failure"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.ChangeMessageVisibilityBatchInput:
+								extendTimeout.Store(params.Entries[0].VisibilityTimeout)
+
+								return middleware.InitializeOutput{
+									Result: &sqs.ChangeMessageVisibilityBatchOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteMessageBatchInput:
+								ids := make([]string, 0, len(params.Entries))
+								for _, entry := range params.Entries {
+									ids = append(ids, *entry.Id)
+								}
+								deleted.Store(&ids)
+
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteMessageBatchOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteQueueInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteQueueOutput{},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	notifier := ksns.NewNotifier("arn:aws:sns:us-west-2:123456789012:MyTopic",
+		ksns.WithAWSConfig(cfg),
+		ksns.WithVisibilityTimeout(500*time.Millisecond),
+		ksns.WithLogHandler(logHandler(&buffer{})),
+	)
+	notifier.Register(&slowLoader{cancel: cancel, delay: 600 * time.Millisecond})
+	assert.NoError(t, notifier.Start(ctx))
+
+	assert.Equal(t, int32(1), receiveTimeout.Load())
+	assert.Equal(t, int32(1), extendTimeout.Load())
+	assert.Equal(t, []string{"success"}, *deleted.Load())
+}
+
+func TestNotifier_filter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		deleted atomic.Pointer[[]string]
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg, err := config.LoadDefaultConfig(ctx,
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(
+					middleware.InitializeMiddlewareFunc(
+						"mock",
+						func(
+							_ context.Context,
+							input middleware.InitializeInput,
+							_ middleware.InitializeHandler,
+						) (middleware.InitializeOutput, middleware.Metadata, error) {
+							switch params := input.Parameters.(type) {
+							case *sts.GetCallerIdentityInput:
+								return middleware.InitializeOutput{
+									Result: &sts.GetCallerIdentityOutput{
+										Arn: aws.String("arn:aws:sts::123456789012:assumed-role/role-name/session-name"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.CreateQueueInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.CreateQueueOutput{
+										QueueUrl: aws.String("https://sqs.us-west-2.amazonaws.com/123456789012/MyQueue"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.GetQueueAttributesInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.GetQueueAttributesOutput{
+										Attributes: map[string]string{
+											"QueueArn": "arn:aws:sqs:us-west-2:123456789012:MyQueue",
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.SubscribeInput:
+								return middleware.InitializeOutput{
+									Result: &sns.SubscribeOutput{
+										SubscriptionArn: aws.String("arn:aws:sns:us-west-2:123456789012:MyTopic:subscription"),
+									},
+								}, middleware.Metadata{}, nil
+							case *sns.UnsubscribeInput:
+								return middleware.InitializeOutput{
+									Result: &sns.UnsubscribeOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.ReceiveMessageInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.ReceiveMessageOutput{
+										Messages: []types.Message{
+											{
+												MessageId:     aws.String("dropped"),
+												ReceiptHandle: aws.String("receipt-handle-dropped"),
+												Body:          aws.String("dropped"),
+											},
+											{
+												MessageId:     aws.String("message"),
+												ReceiptHandle: aws.String("receipt-handle-message"),
+												Body:          aws.String("message"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteMessageBatchInput:
+								ids := make([]string, 0, len(params.Entries))
+								for _, entry := range params.Entries {
+									ids = append(ids, *entry.Id)
+								}
+								deleted.Store(&ids)
+
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteMessageBatchOutput{},
+								}, middleware.Metadata{}, nil
+							case *sqs.DeleteQueueInput:
+								return middleware.InitializeOutput{
+									Result: &sqs.DeleteQueueOutput{},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	notifier := ksns.NewNotifier("arn:aws:sns:us-west-2:123456789012:MyTopic",
+		ksns.WithAWSConfig(cfg),
+		ksns.WithFilter(func(msg []byte) bool { return string(msg) != "dropped" }),
+		ksns.WithLogHandler(logHandler(&buffer{})),
+	)
+	loader := &loader{cancel: cancel}
+	notifier.Register(loader)
+	assert.NoError(t, notifier.Start(ctx))
+
+	assert.Equal(t, int32(1), loader.count.Load())
+	assert.Equal(t, []string{"dropped", "message"}, *deleted.Load())
+}
+
+// slowLoader processes the message slowly, and fails on the message "failure".
+type slowLoader struct {
+	cancel context.CancelFunc
+	delay  time.Duration
+}
+
+func (l *slowLoader) OnEvent(msg []byte) error {
+	time.Sleep(l.delay)
+	if string(msg) == "failure" {
+		l.cancel()
+
+		return errors.New("process message error")
+	}
+
+	return nil
+}
+
 type loader struct {
 	notified atomic.Bool
+	count    atomic.Int32
 	cancel   context.CancelFunc
 	err      error
 }
 
 func (l *loader) OnEvent([]byte) error {
 	l.notified.Store(true)
+	l.count.Add(1)
 	l.cancel()
 
 	return l.err
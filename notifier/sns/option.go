@@ -4,9 +4,13 @@
 package sns
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 )
 
 // WithAWSConfig provides the AWS Config for the AWS SDK.
@@ -18,6 +22,100 @@ func WithAWSConfig(config aws.Config) Option {
 	}
 }
 
+// WithTopics provides additional SNS topic Names or ARNs to the topic given to NewNotifier.
+// The Notifier subscribes a single SQS queue to all topics,
+// so that one Notifier can watch changes from multiple sources.
+func WithTopics(topics ...string) Option {
+	return func(options *options) {
+		options.topics = append(options.topics, topics...)
+	}
+}
+
+// WithReceiveOptions provides the maximum number of messages and the wait time in seconds
+// for receiving messages from the SQS queue.
+// The maxMessages must be in [1, 10] and the waitTime must be in [0, 20] as required by SQS,
+// otherwise Notifier.Start returns an error.
+//
+// By default, it receives at most 10 messages with 20 seconds long polling.
+func WithReceiveOptions(maxMessages int32, waitTime int32) Option {
+	return func(options *options) {
+		if maxMessages < 1 || maxMessages > 10 {
+			options.err = errors.Join(options.err,
+				fmt.Errorf("max number of messages %d is out of range [1, 10]", maxMessages)) //nolint:err113
+		}
+		if waitTime < 0 || waitTime > 20 {
+			options.err = errors.Join(options.err,
+				fmt.Errorf("wait time %d seconds is out of range [0, 20]", waitTime)) //nolint:err113
+		}
+		options.maxMessages = maxMessages
+		options.waitTime = waitTime
+	}
+}
+
+// WithRetryInterval provides the interval before receiving messages again
+// after it fails to receive messages from the SQS queue.
+// The non-positive interval is ignored.
+//
+// By default, it retries after 20 seconds.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(options *options) {
+		if interval > 0 {
+			options.retryInterval = interval
+		}
+	}
+}
+
+// WithVisibilityTimeout provides the visibility timeout of received messages,
+// which is rounded up to seconds and capped at 12 hours as required by SQS.
+// The visibility of in-flight messages is extended periodically while loaders are processing them,
+// so slow loaders do not cause the messages to be redelivered.
+//
+// Regardless of this option, only the messages processed successfully are deleted from the queue.
+// The failed messages are redelivered after the visibility timeout,
+// and flow to the dead-letter queue of WithDeadLetterQueue after repeated failures.
+//
+// By default, it uses the visibility timeout of the queue, which is 30 seconds.
+func WithVisibilityTimeout(timeout time.Duration) Option {
+	return func(options *options) {
+		if timeout > 0 {
+			options.visibilityTimeout = min((timeout + time.Second - 1).Truncate(time.Second), 12*time.Hour) //nolint:mnd
+		}
+	}
+}
+
+// WithDeadLetterQueue provides the ARN of the SQS dead-letter queue and the maximum receive count,
+// which is set as the redrive policy of the queue created by the Notifier.
+// The message that fails to be processed maxReceiveCount times is moved to the dead-letter queue.
+// The dead-letter queue must be in the same account and region as the Notifier,
+// and the maxReceiveCount must be in [1, 1000], otherwise Notifier.Start returns an error.
+//
+// By default, there is no dead-letter queue, so the failed messages are redelivered
+// until they are processed successfully or the queue is deleted when the Notifier stops.
+func WithDeadLetterQueue(queueArn string, maxReceiveCount int) Option {
+	return func(options *options) {
+		if !arn.IsARN(queueArn) {
+			options.err = errors.Join(options.err,
+				fmt.Errorf("dead-letter queue %s is not an ARN", queueArn)) //nolint:err113
+		}
+		if maxReceiveCount < 1 || maxReceiveCount > 1000 {
+			options.err = errors.Join(options.err,
+				fmt.Errorf("max receive count %d is out of range [1, 1000]", maxReceiveCount)) //nolint:err113
+		}
+		options.deadLetterQueue = queueArn
+		options.maxReceiveCount = maxReceiveCount
+	}
+}
+
+// WithFilter provides the function to filter the raw messages before fanout to registered loaders.
+// The messages that the filter returns false are deleted from the queue and dropped.
+//
+// By default, all messages are fanned out to registered loaders.
+func WithFilter(filter func(msg []byte) bool) Option {
+	return func(options *options) {
+		options.filter = filter
+	}
+}
+
 // WithLogHandler provides the slog.Handler for logs from notifier.
 //
 // By default, it uses handler from slog.Default().
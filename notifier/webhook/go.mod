@@ -0,0 +1,3 @@
+module github.com/nil-go/konf/notifier/webhook
+
+go 1.22
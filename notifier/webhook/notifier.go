@@ -0,0 +1,170 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package webhook provides a notifier that receives change events of configuration via HTTP POST requests.
+//
+// The Notifier is a http.Handler, which should be mounted on the HTTP server of the application.
+// If the secret is provided by WithSecret, the request must be signed with HMAC-SHA256
+// of the request body, and the signature is in the X-Signature-256 header
+// with format "sha256=<hex encoded signature>".
+//
+// It responds with:
+//   - 200 OK if the event has been processed by a registered loader;
+//   - 400 Bad Request if the request body is empty;
+//   - 401 Unauthorized if the signature is missing or mismatched;
+//   - 405 Method Not Allowed if the request method is not POST;
+//   - 422 Unprocessable Entity if no loader accepts the event;
+//   - 500 Internal Server Error if the loader fails to process the event, so the sender could retry it.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SignatureHeader is the header of the HMAC-SHA256 signature of the request body.
+const SignatureHeader = "X-Signature-256"
+
+// Notifier that receives change events via HTTP POST requests.
+//
+// To create a new Notifier, call [NewNotifier].
+type Notifier struct {
+	secret []byte
+	logger *slog.Logger
+
+	loaders      []loader
+	loadersMutex sync.RWMutex
+}
+
+type loader interface{ OnEvent([]byte) error }
+
+// NewNotifier creates a Notifier with the given Option(s).
+func NewNotifier(opts ...Option) *Notifier {
+	option := &options{}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Notifier)(option)
+}
+
+// Register registers a loader to the Notifier.
+func (n *Notifier) Register(loaders ...loader) {
+	if n == nil {
+		return
+	}
+
+	n.loadersMutex.Lock()
+	defer n.loadersMutex.Unlock()
+	n.loaders = append(n.loaders, loaders...)
+}
+
+var errNil = errors.New("nil Notifier")
+
+// ServeHTTP receives the change event in the request body and fanout to registered loaders.
+func (n *Notifier) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if n == nil {
+		http.Error(writer, errNil.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	ctx := request.Context()
+	logger := n.logger
+	if n.logger == nil {
+		logger = slog.Default()
+	}
+
+	if request.Method != http.MethodPost {
+		writer.Header().Set("Allow", http.MethodPost)
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	bytes, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxBodySize))
+	if err != nil {
+		http.Error(writer, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+	if len(n.secret) > 0 && !n.verify(bytes, request.Header.Get(SignatureHeader)) {
+		logger.LogAttrs(ctx, slog.LevelWarn,
+			"Reject message with invalid signature.",
+			slog.String("remote", request.RemoteAddr),
+		)
+		http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+
+		return
+	}
+	if len(bytes) == 0 {
+		http.Error(writer, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+
+		return
+	}
+
+	n.loadersMutex.RLock()
+	loaders := slices.Clone(n.loaders)
+	n.loadersMutex.RUnlock()
+
+	errM := errors.ErrUnsupported
+	for _, loader := range loaders {
+		errM = loader.OnEvent(bytes)
+		if errors.Is(errM, errors.ErrUnsupported) {
+			continue
+		}
+
+		if errM != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn,
+				"Fail to process message.",
+				slog.String("msg", string(bytes)),
+				slog.Any("loader", loader),
+				slog.Any("error", errM),
+			)
+		}
+
+		break
+	}
+	if errors.Is(errM, errors.ErrUnsupported) {
+		logger.LogAttrs(ctx, slog.LevelWarn,
+			"No loader to process message.",
+			slog.String("msg", string(bytes)),
+		)
+		http.Error(writer, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
+
+		return
+	}
+	if errM != nil {
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+}
+
+const maxBodySize = 1 << 20 // 1 MiB is large enough for change events.
+
+func (n *Notifier) verify(body []byte, signature string) bool {
+	signature, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, n.secret)
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package webhook_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nil-go/konf/notifier/webhook"
+	"github.com/nil-go/konf/notifier/webhook/internal/assert"
+)
+
+func TestNotifier_nil(t *testing.T) {
+	t.Parallel()
+
+	var n *webhook.Notifier
+	n.Register(nil) // no panic
+	recorder := httptest.NewRecorder()
+	n.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("message")))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestNotifier(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []webhook.Option
+		method      string
+		body        string
+		signature   string
+		errLoader   error
+		notified    bool
+		status      int
+		log         string
+	}{
+		{
+			description: "success",
+			body:        "message",
+			notified:    true,
+			status:      http.StatusOK,
+		},
+		{
+			description: "with secret",
+			opts:        []webhook.Option{webhook.WithSecret([]byte("secret"))},
+			body:        "message",
+			signature:   sign("secret", "message"),
+			notified:    true,
+			status:      http.StatusOK,
+		},
+		{
+			description: "unsigned",
+			opts:        []webhook.Option{webhook.WithSecret([]byte("secret"))},
+			body:        "message",
+			status:      http.StatusUnauthorized,
+			log: `level=WARN msg="Reject message with invalid signature." remote=192.0.2.1:1234
+`,
+		},
+		{
+			description: "signature mismatch",
+			opts:        []webhook.Option{webhook.WithSecret([]byte("secret"))},
+			body:        "message",
+			signature:   sign("other", "message"),
+			status:      http.StatusUnauthorized,
+			log: `level=WARN msg="Reject message with invalid signature." remote=192.0.2.1:1234
+`,
+		},
+		{
+			description: "invalid signature",
+			opts:        []webhook.Option{webhook.WithSecret([]byte("secret"))},
+			body:        "message",
+			signature:   "sha256=invalid",
+			status:      http.StatusUnauthorized,
+			log: `level=WARN msg="Reject message with invalid signature." remote=192.0.2.1:1234
+`,
+		},
+		{
+			description: "method not allowed",
+			method:      http.MethodGet,
+			status:      http.StatusMethodNotAllowed,
+		},
+		{
+			description: "empty message",
+			status:      http.StatusBadRequest,
+		},
+		{
+			description: "unsupported message",
+			body:        "message",
+			errLoader:   errors.ErrUnsupported,
+			notified:    true,
+			status:      http.StatusUnprocessableEntity,
+			log: `level=WARN msg="No loader to process message." msg=message
+`,
+		},
+		{
+			description: "process message error",
+			body:        "message",
+			errLoader:   errors.New("process message error"),
+			notified:    true,
+			status:      http.StatusInternalServerError,
+			log: `level=WARN msg="Fail to process message." msg=message loader=loader error="process message error"
+`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			notifier := webhook.NewNotifier(append(testcase.opts, webhook.WithLogHandler(logHandler(buf)))...)
+			loader := &loader{err: testcase.errLoader}
+			notifier.Register(loader)
+
+			method := testcase.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			request := httptest.NewRequest(method, "/", strings.NewReader(testcase.body))
+			if testcase.signature != "" {
+				request.Header.Set(webhook.SignatureHeader, testcase.signature)
+			}
+			recorder := httptest.NewRecorder()
+			notifier.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testcase.status, recorder.Code)
+			assert.Equal(t, testcase.notified, loader.notified)
+			assert.Equal(t, testcase.log, buf.String())
+		})
+	}
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	return fmt.Sprintf("sha256=%s", hex.EncodeToString(mac.Sum(nil)))
+}
+
+type loader struct {
+	notified bool
+	err      error
+}
+
+func (l *loader) OnEvent([]byte) error {
+	l.notified = true
+
+	return l.err
+}
+
+func (l *loader) String() string {
+	return "loader"
+}
+
+func logHandler(buf *bytes.Buffer) *slog.TextHandler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package webhook
+
+import (
+	"log/slog"
+)
+
+// WithSecret provides the secret for verifying the HMAC-SHA256 signature of requests.
+// Requests without valid signature are rejected with 401 Unauthorized.
+//
+// By default, it accepts all requests without verification.
+func WithSecret(secret []byte) Option {
+	return func(options *options) {
+		options.secret = secret
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from notifier.
+//
+// By default, it uses handler from slog.Default().
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the Notifier with specific options.
+	Option  func(options *options)
+	options Notifier
+)
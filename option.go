@@ -4,7 +4,12 @@
 package konf
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"log/slog"
+	"regexp"
+	"time"
 
 	"github.com/nil-go/konf/internal/convert"
 )
@@ -19,6 +24,18 @@ func WithDelimiter(delimiter string) Option {
 	}
 }
 
+// WithPathParser provides the function used to split config paths into keys,
+// e.g. parsing JSON Pointer `/a/first.last` for the keys which contain the delimiter.
+// It takes precedence over the delimiter when splitting paths, and the path has been
+// converted to lower case before parsing unless konf.WithCaseSensitive is set.
+//
+// By default, it splits the path by the delimiter.
+func WithPathParser(parser func(path string) []string) Option {
+	return func(options *options) {
+		options.pathParser = parser
+	}
+}
+
 // WithTagName provides the tag name that reads for field names.
 // The tag name is used when decoding configuration into structs.
 //
@@ -35,14 +52,230 @@ func WithTagName(tagName string) Option {
 // It can be either `func(F) (T, error)` which returns the converted value,
 // or `func(F, T) error` which sets the converted value inline.
 //
-// By default, it composes string to time.Duration, string to []string split by `,`
-// and string to encoding.TextUnmarshaler.
+// By default, it composes string to time.Duration, string to time.Time in time.RFC3339,
+// string to []string split by `,`, string to url.URL, net.IP and netip.Addr,
+// string to encoding.TextUnmarshaler, and any value to json.RawMessage by re-marshalling it as JSON,
+// e.g. map[string]json.RawMessage for the opaque configuration parsed later by its owner.
+// The hooks also apply to map keys, so a key type implementing encoding.TextUnmarshaler
+// is decoded (and validated) by its UnmarshalText.
 func WithDecodeHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) Option {
 	return func(options *options) {
 		options.convertOpts = append(options.convertOpts, convert.WithHook[F, T](hook))
 	}
 }
 
+// WithErrorUnused enables reporting error while decoding a map into a struct
+// if the map has keys that are not used by any field of the struct,
+// e.g. `databse` for field `Database`. It helps to detect typos in configuration.
+// The unused keys of nested structs are reported in a single error, e.g. `invalid keys: databse, server.prot`.
+func WithErrorUnused() Option {
+	return func(options *options) {
+		options.errorUnused = true
+	}
+}
+
+// WithDurationUnit provides the unit of numeric values while decoding them into time.Duration,
+// e.g. with unit time.Second, both `5` (from JSON) and `"5s"` (from YAML) decode to 5 seconds.
+// The numeric string like `"5"` (e.g. from environment variables) is also in the given unit.
+//
+// By default, numeric values are decoded as nanoseconds.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(options *options) {
+		options.durationUnit = unit
+	}
+}
+
+// WithTimeLayouts provides additional layouts for parsing strings into time.Time,
+// which are tried in order after time.RFC3339, e.g. time.DateOnly for `2024-01-02`.
+//
+// By default, strings are parsed as time.RFC3339 only.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(options *options) {
+		options.timeLayouts = append(options.timeLayouts, layouts...)
+	}
+}
+
+// WithBase64Decode enables decoding strings in standard base64 encoding into []byte,
+// e.g. for secrets and keys. The invalid base64 string returns an error.
+// If both WithBase64Decode and WithHexDecode are provided, the last one takes effect.
+//
+// By default, the raw bytes of the string are copied into []byte.
+func WithBase64Decode() Option {
+	return func(options *options) {
+		options.bytesDecoder = func(from string) ([]byte, error) {
+			bytes, err := base64.StdEncoding.DecodeString(from)
+			if err != nil {
+				return nil, fmt.Errorf("decode base64: %w", err)
+			}
+
+			return bytes, nil
+		}
+	}
+}
+
+// WithHexDecode enables decoding hexadecimal strings into []byte, e.g. for secrets and keys.
+// The invalid hexadecimal string returns an error.
+// If both WithBase64Decode and WithHexDecode are provided, the last one takes effect.
+//
+// By default, the raw bytes of the string are copied into []byte.
+func WithHexDecode() Option {
+	return func(options *options) {
+		options.bytesDecoder = func(from string) ([]byte, error) {
+			bytes, err := hex.DecodeString(from)
+			if err != nil {
+				return nil, fmt.Errorf("decode hex: %w", err)
+			}
+
+			return bytes, nil
+		}
+	}
+}
+
+// WithWeaklyTypedInput enables unwrapping the slice/array with a single element
+// while decoding it into a scalar, e.g. `["8080"]` into an int field.
+// The slice/array with more than one element returns an error.
+//
+// By default, decoding a slice/array into a scalar returns an error,
+// so it does not mask the real type mismatches.
+func WithWeaklyTypedInput() Option {
+	return func(options *options) {
+		options.weaklyTyped = true
+	}
+}
+
+// WithIntBase provides the base for parsing strings into integers, e.g. 16 for `ff` as 255.
+// The underscore digit separators are allowed, e.g. `1_000`.
+//
+// By default, the base is implied by the string's prefix following the syntax for integer literals
+// in Go, e.g. `0xff` is 255 and `1_000` is 1000.
+func WithIntBase(base int) Option {
+	return func(options *options) {
+		options.intBase = base
+	}
+}
+
+// WithEnvExpand enables expanding the references of environment variables
+// in the string values loaded by all loaders, e.g. `postgres://${DB_HOST}:5432/app`.
+// Both `${VAR}` and `$VAR` are replaced with the value of the environment variable,
+// and `$$` is replaced with a literal `$`. The unresolved references are left intact.
+//
+// The values are expanded while loading, so the environment variables are read
+// when the loader loads or changes the configuration, unless WithInterpolation is set.
+func WithEnvExpand() Option {
+	return func(options *options) {
+		options.expandEnv = expandEnv(false)
+	}
+}
+
+// WithStrictEnvExpand works like WithEnvExpand, but it returns an error from Config.Load
+// if the referenced environment variable is not set.
+// While watching, the change with unresolved references is ignored with a warning log.
+func WithStrictEnvExpand() Option {
+	return func(options *options) {
+		options.expandEnv = expandEnv(true)
+	}
+}
+
+// WithInterpolation enables resolving the references of other paths
+// in the string values while unmarshalling, e.g. `https://${host}:${port}`,
+// so it reflects the latest values of the referenced paths while watching.
+// The path of the reference is from the root even for the sub Config created by Config.Sub.
+// The value which is only a reference (e.g. `${server.port}`) resolves to the referenced value as it is,
+// and `$$` is replaced with a literal `$`. The unresolved references are left intact,
+// and Config.Unmarshal returns an error naming the cycle if references form a cycle.
+//
+// If it's used with WithEnvExpand or WithStrictEnvExpand, the environment variables are also expanded
+// while unmarshalling rather than loading, and the paths take precedence over the environment variables,
+// i.e. the reference is resolved from the environment variable only if the path does not exist.
+// The environment variables are expanded once, so the `$` in the resolved value of a path is kept as it is.
+func WithInterpolation() Option {
+	return func(options *options) {
+		options.interpolation = true
+	}
+}
+
+// WithLazyResolution enables resolving the value of a path in Config.Unmarshal
+// by querying loaders from the highest precedence, and stopping at the first loader which has the path,
+// rather than reading from the configuration merged from all loaders.
+//
+// Comparing to the default eager merge, the nested maps under the path are not merged across loaders,
+// e.g. with `{a: {b: 1}}` from the first loader and `{a: {c: 2}}` from the second loader,
+// path `a` resolves to `{c: 2}` rather than `{b: 1, c: 2}`.
+// It does not change when the callbacks registered by Config.OnChange are executed.
+func WithLazyResolution() Option {
+	return func(options *options) {
+		options.lazyResolution = true
+	}
+}
+
+// WithWatcherAutoRestart enables restarting the watcher after the given backoff
+// if its Watch returns nil before the context of Config.Watch is done,
+// e.g. the underlying stream is closed. It logs a warning for each restart.
+//
+// By default, the watcher is not restarted, and the configuration stops updating from it.
+func WithWatcherAutoRestart(backoff time.Duration) Option {
+	return func(options *options) {
+		options.restartBackoff = backoff
+	}
+}
+
+// WithMergeFunc provides the function to merge the values of the same path from different loaders,
+// which is called when a later loader defines a path that has been defined by earlier loaders,
+// unless both values are maps, which are merged recursively.
+// It receives the full path as keys (in lower case unless konf.WithCaseSensitive is set),
+// the existing value and the incoming value from the later loader, and returns the merged value.
+// It must not modify the existing or incoming value.
+//
+// For example, it could append the incoming slice to the existing slice for specific paths.
+//
+// By default, the incoming value replaces the existing value.
+func WithMergeFunc(merge func(path []string, existing, incoming any) any) Option {
+	return func(options *options) {
+		options.providers.merge = merge
+	}
+}
+
+// WithDuplicateKeyHandler provides the handler which is called when a later loader
+// overrides the value of a path that has been defined by earlier loaders,
+// unless both values are maps, which are merged recursively.
+// It receives the path joined by the delimiter (in lower case unless konf.WithCaseSensitive is set),
+// and the names of the loaders defining the path, ordered by precedence from low to high.
+// For example, it could log the conflicting keys, or panic in tests.
+//
+// It's called whenever the configuration is merged, e.g. loading a loader or applying changes from watchers,
+// so it must not call methods of the Config.
+func WithDuplicateKeyHandler(handler func(path string, loaders []string)) Option {
+	return func(options *options) {
+		options.duplicateKeyHandler = handler
+	}
+}
+
+// WithBlurOnMarshal enables blurring sensitive information (e.g. passwords and tokens) in Config.Marshal.
+func WithBlurOnMarshal() Option {
+	return func(options *options) {
+		options.blurOnMarshal = true
+	}
+}
+
+// WithBlurPatterns provides the additional patterns for blurring sensitive information
+// in Config.Explain, Config.ExportEnv and Config.Marshal with WithBlurOnMarshal.
+// The value of path matching any of namePatterns is blurred as `******`,
+// and the value matching any of valuePatterns is blurred as the name of the pattern,
+// e.g. `{"Corp Token": regexp.MustCompile("corp-tok-[A-Z0-9]{20}")}`.
+//
+// They augment the built-in patterns, e.g. `password` for paths and AWS API key for values.
+func WithBlurPatterns(namePatterns []*regexp.Regexp, valuePatterns map[string]*regexp.Regexp) Option {
+	return func(options *options) {
+		options.blurPatterns.Names = append(options.blurPatterns.Names, namePatterns...)
+		if len(valuePatterns) > 0 && options.blurPatterns.Values == nil {
+			options.blurPatterns.Values = make(map[string]*regexp.Regexp, len(valuePatterns))
+		}
+		for name, pattern := range valuePatterns {
+			options.blurPatterns.Values[name] = pattern
+		}
+	}
+}
+
 // WithLogHandler provides the slog.Handler for logs from watch.
 //
 // By default, it uses handler from slog.Default().
@@ -61,14 +294,30 @@ func WithOnStatus(onStatus func(loader Loader, changed bool, err error)) Option
 	}
 }
 
-// WithCaseSensitive enables the case sensitivity of the configuration keys.
+// WithMetrics provides the MetricsRecorder for recording metrics of configuration loading/watching.
+// It records the load duration and errors from Config.Load,
+// and the changes and errors while watching.
+//
+// By default, it does not record any metrics.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(options *options) {
+		options.metrics = recorder
+	}
+}
+
+// WithCaseSensitive enables the case sensitivity of the configuration keys,
+// including the paths, the struct fields and the map keys.
+// It takes precedence over konf.WithMapKeyCaseSensitive.
 func WithCaseSensitive() Option {
 	return func(options *options) {
 		options.caseSensitive = true
 	}
 }
 
-// WithMapKeyCaseSensitive enables the case sensitivity of the map keys.
+// WithMapKeyCaseSensitive enables the case sensitivity of the map keys,
+// e.g. `X-Request-ID` is preserved while decoding into map[string]string.
+// The paths and the struct fields are still case-insensitive unless konf.WithCaseSensitive is set,
+// which keeps the keys as they are, so this option has no effect with it.
 func WithMapKeyCaseSensitive() Option {
 	return func(options *options) {
 		options.mapKeyCaseSensitive = true
@@ -81,7 +330,60 @@ type (
 	options struct {
 		Config
 
-		tagName     string
+		tagName      string
+		errorUnused  bool
+		durationUnit time.Duration
+		timeLayouts  []string
+		bytesDecoder func(string) ([]byte, error)
+		intBase      int
+		weaklyTyped  bool
+
+		duplicateKeyHandler func(path string, loaders []string)
+	}
+)
+
+// DecodeHook provides the decode hook for a single call of Config.UnmarshalWith.
+// It takes precedence over the decode hooks of the Config.
+//
+// It can be either `func(F) (T, error)` which returns the converted value,
+// or `func(F, T) error` which sets the converted value inline.
+func DecodeHook[F, T any, FN func(F) (T, error) | func(F, T) error](hook FN) DecodeOption {
+	return func(options *decodeOptions) {
+		options.convertOpts = append(options.convertOpts, convert.WithHook[F, T](hook))
+	}
+}
+
+// DecodeErrorUnused enables reporting error for a single call of Config.UnmarshalWith
+// if the map has keys that are not used by any field of the struct.
+// See konf.WithErrorUnused for details.
+func DecodeErrorUnused() DecodeOption {
+	return func(options *decodeOptions) {
+		options.convertOpts = append(options.convertOpts, convert.WithErrorUnused())
+	}
+}
+
+type (
+	// DecodeOption configures a single call of Config.UnmarshalWith with specific options,
+	// or the subsequent calls of unmarshalling via Config.AddDecodeOptions.
+	DecodeOption  func(*decodeOptions)
+	decodeOptions struct {
 		convertOpts []convert.Option
 	}
 )
+
+// ExplainReveal reveals the sensitive information (e.g. passwords and tokens)
+// for a single call of Config.ExplainWith instead of blurring it.
+// It should only be used for trusted debugging, e.g. on the local machine.
+func ExplainReveal() ExplainOption {
+	return func(options *explainOptions) {
+		options.reveal = true
+	}
+}
+
+type (
+	// ExplainOption configures a single call of Config.ExplainWith with specific options.
+	ExplainOption  func(*explainOptions)
+	explainOptions struct {
+		reveal bool
+	}
+)
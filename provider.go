@@ -5,6 +5,7 @@ package konf
 
 import (
 	"context"
+	"slices"
 )
 
 // Loader is the interface that wraps the Load method.
@@ -40,5 +41,6 @@ func (c *Config) Exists(path []string) bool {
 	}
 	c.nocopy.Check()
 
-	return c.providers.sub(path) != nil
+	// It does not resolve the lazy loaders since it's called by the loaders while loading.
+	return c.root().providers.peek(append(slices.Clip(c.prefix), path...)) != nil
 }
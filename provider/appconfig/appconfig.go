@@ -38,6 +38,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
 	"github.com/aws/aws-sdk-go-v2/service/appconfigdata"
+
+	"github.com/nil-go/konf/provider/appconfig/internal/watch"
 )
 
 // AppConfig is a Provider that loads configuration from AWS AppConfig.
@@ -45,9 +47,12 @@ import (
 // To create a new AppConfig, call [New].
 type AppConfig struct {
 	unmarshal    func([]byte, any) error
+	featureFlags bool
 	pollInterval time.Duration
+	debounce     time.Duration
 
-	onStatus  func(bool, error)
+	ctx       context.Context //nolint:containedctx
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -78,7 +83,13 @@ func (a *AppConfig) Load() (map[string]any, error) {
 		return nil, errNil
 	}
 
-	values, _, err := a.load(context.Background())
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	values, _, err := a.load(ctx)
+	a.reporter.Load(ctx, a, err, start)
 
 	return values, err
 }
@@ -95,23 +106,37 @@ func (a *AppConfig) Watch(ctx context.Context, onChange func(map[string]any)) er
 	if pollInterval == 0 {
 		pollInterval = time.Minute
 	}
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	// It schedules the next poll after the poll interval,
+	// but not earlier than the next poll time required by AppConfig.
+	poll := time.NewTimer(max(pollInterval, a.client.untilNextPoll()))
+	defer poll.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-ticker.C:
+		case <-poll.C:
 			a.changed()
 		case <-a.changedCh:
-			values, changed, err := a.load(ctx)
-			if a.onStatus != nil {
-				a.onStatus(changed, err)
+			// Collapse the changes arriving within the debounce window into one load,
+			// and wait until the next poll time required by AppConfig.
+			if !watch.Debounce(ctx, max(a.debounce, a.client.untilNextPoll()), a.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := a.load(ctx)
+			a.reporter.Watch(ctx, a, changed, err, start)
 			if changed {
 				onChange(values)
 			}
+
+			if !poll.Stop() {
+				select {
+				case <-poll.C:
+				default:
+				}
+			}
+			poll.Reset(max(pollInterval, a.client.untilNextPoll()))
 		}
 	}
 }
@@ -131,7 +156,10 @@ func (a *AppConfig) load(ctx context.Context) (map[string]any, bool, error) {
 	}
 
 	unmarshal := a.unmarshal
-	if unmarshal == nil {
+	switch {
+	case a.featureFlags:
+		unmarshal = unmarshalFeatureFlags
+	case unmarshal == nil:
 		unmarshal = json.Unmarshal
 	}
 	var values map[string]any
@@ -142,6 +170,46 @@ func (a *AppConfig) load(ctx context.Context) (map[string]any, bool, error) {
 	return values, true, nil
 }
 
+// unmarshalFeatureFlags unmarshals the feature flag document into target,
+// which must be a *map[string]any.
+//
+// The document in the format of feature flag profile, e.g.
+// {"flags": {"myflag": {"name": "My Flag"}}, "values": {"myflag": {"enabled": true, "attr": "v"}}, "version": "1"},
+// is converted to {"myflag": {"enabled": true, "attr": "v"}}, and the flags without values are disabled.
+// Otherwise, the document is in the evaluated format returned by AppConfig data plane,
+// which already has each flag's enabled state plus its attributes under the flag key.
+func unmarshalFeatureFlags(bytes []byte, target any) error {
+	var document struct {
+		Flags   map[string]any            `json:"flags"`
+		Values  map[string]map[string]any `json:"values"`
+		Version string                    `json:"version"`
+	}
+	if err := json.Unmarshal(bytes, &document); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if document.Version == "" {
+		return json.Unmarshal(bytes, target) //nolint:wrapcheck
+	}
+
+	values, ok := target.(*map[string]any)
+	if !ok {
+		return fmt.Errorf("unsupported target type %T: %w", target, errors.ErrUnsupported)
+	}
+	*values = make(map[string]any, len(document.Flags))
+	for name := range document.Flags {
+		(*values)[name] = map[string]any{"enabled": false}
+	}
+	for name, value := range document.Values {
+		flag := map[string]any{"enabled": false}
+		for key, val := range value {
+			flag[key] = val
+		}
+		(*values)[name] = flag
+	}
+
+	return nil
+}
+
 func (a *AppConfig) OnEvent(msg []byte) error { //nolint:cyclop,funlen
 	if a == nil {
 		return errNil
@@ -221,9 +289,19 @@ func (a *AppConfig) OnEvent(msg []byte) error { //nolint:cyclop,funlen
 }
 
 func (a *AppConfig) Status(onStatus func(bool, error)) {
-	a.onStatus = onStatus
+	a.reporter.OnStatus = onStatus
+}
+
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (a *AppConfig) StatusDetailed(onStatus func(StatusInfo)) {
+	a.reporter.OnStatusDetailed = onStatus
 }
 
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (a *AppConfig) String() string {
 	return "appconfig://" + a.client.application + "/" + a.client.profile
 }
@@ -254,11 +332,6 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
 		p.client = appconfigdata.NewFromConfig(p.config)
 	}
 
-	if nextPollTime := p.nextPollTime.Load(); nextPollTime != nil && time.Now().Before(*nextPollTime) {
-		// Have to wait until the next poll time.
-		time.Sleep(time.Until(*nextPollTime))
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
 	defer cancel()
 
@@ -289,6 +362,16 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
 	return resp.Configuration, len(resp.Configuration) > 0, nil
 }
 
+// untilNextPoll returns the duration until the next poll time required by AppConfig,
+// which is not positive if it could poll now.
+func (p *clientProxy) untilNextPoll() time.Duration {
+	if nextPollTime := p.nextPollTime.Load(); nextPollTime != nil {
+		return time.Until(*nextPollTime)
+	}
+
+	return 0
+}
+
 func (p *clientProxy) ensureApplicationID(applicationID string) error {
 	if p.applicationID != "" || applicationID == "" {
 		return nil
@@ -4,8 +4,10 @@
 package appconfig_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -44,6 +46,7 @@ func TestAppConfig_Load(t *testing.T) {
 			middleware.FinalizeHandler,
 		) (middleware.FinalizeOutput, middleware.Metadata, error)
 		unmarshal func([]byte, any) error
+		opts      []kappconfig.Option
 		expected  map[string]any
 		err       string
 	}{
@@ -86,6 +89,87 @@ func TestAppConfig_Load(t *testing.T) {
 				"k": "v",
 			},
 		},
+		{
+			description: "feature flags",
+			middleware: func(
+				ctx context.Context,
+				input middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "StartConfigurationSession":
+					return middleware.FinalizeOutput{
+						Result: &appconfigdata.StartConfigurationSessionOutput{
+							InitialConfigurationToken: aws.String("initial-token"),
+						},
+					}, middleware.Metadata{}, nil
+				case "GetLatestConfiguration":
+					if ct := input.Request.(*http.Request).URL.Query().Get("configuration_token"); ct == "next-token" {
+						return middleware.FinalizeOutput{
+							Result: &appconfigdata.GetLatestConfigurationOutput{
+								Configuration:              []byte{},
+								NextPollConfigurationToken: aws.String("next-token"),
+								NextPollIntervalInSeconds:  60,
+							},
+						}, middleware.Metadata{}, nil
+					}
+
+					return middleware.FinalizeOutput{
+						Result: &appconfigdata.GetLatestConfigurationOutput{
+							Configuration:              []byte(`{"flags":{"on":{"name":"On"},"off":{"name":"Off"}},"values":{"on":{"enabled":true,"limit":10}},"version":"1"}`),
+							NextPollConfigurationToken: aws.String("next-token"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			opts: []kappconfig.Option{kappconfig.WithFeatureFlags()},
+			expected: map[string]any{
+				"on":  map[string]any{"enabled": true, "limit": 10.0},
+				"off": map[string]any{"enabled": false},
+			},
+		},
+		{
+			description: "evaluated feature flags",
+			middleware: func(
+				ctx context.Context,
+				input middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "StartConfigurationSession":
+					return middleware.FinalizeOutput{
+						Result: &appconfigdata.StartConfigurationSessionOutput{
+							InitialConfigurationToken: aws.String("initial-token"),
+						},
+					}, middleware.Metadata{}, nil
+				case "GetLatestConfiguration":
+					if ct := input.Request.(*http.Request).URL.Query().Get("configuration_token"); ct == "next-token" {
+						return middleware.FinalizeOutput{
+							Result: &appconfigdata.GetLatestConfigurationOutput{
+								Configuration:              []byte{},
+								NextPollConfigurationToken: aws.String("next-token"),
+								NextPollIntervalInSeconds:  60,
+							},
+						}, middleware.Metadata{}, nil
+					}
+
+					return middleware.FinalizeOutput{
+						Result: &appconfigdata.GetLatestConfigurationOutput{
+							Configuration:              []byte(`{"on":{"enabled":true,"limit":10}}`),
+							NextPollConfigurationToken: aws.String("next-token"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			opts: []kappconfig.Option{kappconfig.WithFeatureFlags()},
+			expected: map[string]any{
+				"on": map[string]any{"enabled": true, "limit": 10.0},
+			},
+		},
 		{
 			description: "start session error",
 			middleware: func(
@@ -179,15 +263,22 @@ func TestAppConfig_Load(t *testing.T) {
 
 			loader := kappconfig.New(
 				"app", "env", "profiler",
-				kappconfig.WithAWSConfig(cfg),
-				kappconfig.WithUnmarshal(testcase.unmarshal),
+				append(
+					testcase.opts,
+					kappconfig.WithAWSConfig(cfg),
+					kappconfig.WithUnmarshal(testcase.unmarshal),
+				)...,
 			)
+			var status kappconfig.StatusInfo
+			loader.StatusDetailed(func(info kappconfig.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 				values, err = loader.Load()
 				assert.NoError(t, err)
 				assert.Equal(t, nil, values)
@@ -197,6 +288,27 @@ func TestAppConfig_Load(t *testing.T) {
 }
 
 //nolint:dupl,gocognit,gocyclo,maintidx
+func TestAppConfig_Load_context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	loader := kappconfig.New("app", "env", "profile",
+		kappconfig.WithAWSConfig(aws.Config{
+			Region: "us-west-2",
+			Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				<-ctx.Done() // Simulate a slow credential provider.
+
+				return aws.Credentials{}, ctx.Err()
+			}),
+		}),
+		kappconfig.WithContext(ctx),
+	)
+	_, err := loader.Load()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
 func TestAppConfig_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -742,6 +854,268 @@ func TestAppConfig_Watch(t *testing.T) {
 	}
 }
 
+func TestAppConfig_Watch_debounce(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []kappconfig.Option
+		loads       func(int32) bool
+	}{
+		{
+			description: "without debounce",
+			loads:       func(loads int32) bool { return loads > 2 },
+		},
+		{
+			description: "with debounce",
+			opts:        []kappconfig.Option{kappconfig.WithDebounce(200 * time.Millisecond)},
+			loads:       func(loads int32) bool { return loads == 1 },
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var loads atomic.Int32
+			cfg, err := config.LoadDefaultConfig(
+				context.Background(),
+				config.WithAPIOptions([]func(*middleware.Stack) error{
+					func(stack *middleware.Stack) error {
+						return stack.Finalize.Add(
+							middleware.FinalizeMiddlewareFunc(
+								"mock",
+								func(
+									ctx context.Context,
+									_ middleware.FinalizeInput,
+									_ middleware.FinalizeHandler,
+								) (middleware.FinalizeOutput, middleware.Metadata, error) {
+									switch awsMiddleware.GetOperationName(ctx) {
+									case "StartConfigurationSession":
+										return middleware.FinalizeOutput{
+											Result: &appconfigdata.StartConfigurationSessionOutput{
+												InitialConfigurationToken: aws.String("initial-token"),
+											},
+										}, middleware.Metadata{}, nil
+									case "GetLatestConfiguration":
+										loads.Add(1)
+
+										return middleware.FinalizeOutput{
+											Result: &appconfigdata.GetLatestConfigurationOutput{
+												Configuration:              []byte{},
+												NextPollConfigurationToken: aws.String("next-token"),
+											},
+										}, middleware.Metadata{}, nil
+									default:
+										return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+									}
+								},
+							),
+							middleware.Before,
+						)
+					},
+				}),
+			)
+			assert.NoError(t, err)
+
+			loader := kappconfig.New(
+				"konf", "test", "profiler",
+				append(testcase.opts, kappconfig.WithAWSConfig(cfg), kappconfig.WithPollInterval(20*time.Millisecond))...,
+			)
+			ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+			defer cancel()
+			assert.NoError(t, loader.Watch(ctx, func(map[string]any) {}))
+			assert.Equal(t, true, testcase.loads(loads.Load()))
+		})
+	}
+}
+
+func TestAppConfig_Watch_nextPollInterval(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							ctx context.Context,
+							_ middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							switch awsMiddleware.GetOperationName(ctx) {
+							case "StartConfigurationSession":
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.StartConfigurationSessionOutput{
+										InitialConfigurationToken: aws.String("initial-token"),
+									},
+								}, middleware.Metadata{}, nil
+							case "GetLatestConfiguration":
+								loads.Add(1)
+
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.GetLatestConfigurationOutput{
+										Configuration:              []byte{},
+										NextPollConfigurationToken: aws.String("next-token"),
+										NextPollIntervalInSeconds:  60,
+									},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	loader := kappconfig.New(
+		"konf", "test", "profiler",
+		kappconfig.WithAWSConfig(cfg), kappconfig.WithPollInterval(20*time.Millisecond),
+	)
+	_, err = loader.Load()
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.NoError(t, loader.Watch(ctx, func(map[string]any) {}))
+	// It does not poll before the next poll time, and stops immediately once ctx is done.
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Equal(t, true, time.Since(start) < time.Second)
+}
+
+func TestAppConfig_StatusDetailed(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							ctx context.Context,
+							_ middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							switch awsMiddleware.GetOperationName(ctx) {
+							case "StartConfigurationSession":
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.StartConfigurationSessionOutput{
+										InitialConfigurationToken: aws.String("initial-token"),
+									},
+								}, middleware.Metadata{}, nil
+							case "GetLatestConfiguration":
+								time.Sleep(10 * time.Millisecond)
+
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.GetLatestConfigurationOutput{
+										Configuration:              []byte(`{"p": {"d": "changed"}}`),
+										NextPollConfigurationToken: aws.String("next-token"),
+									},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	loader := kappconfig.New(
+		"konf", "test", "profiler",
+		kappconfig.WithAWSConfig(cfg), kappconfig.WithPollInterval(20*time.Millisecond),
+	)
+	var status atomic.Pointer[kappconfig.StatusInfo]
+	loader.StatusDetailed(func(info kappconfig.StatusInfo) {
+		status.Store(&info)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Now()
+	assert.NoError(t, loader.Watch(ctx, func(map[string]any) { cancel() }))
+
+	info := status.Load()
+	assert.Equal(t, true, info.Changed)
+	assert.NoError(t, info.Err)
+	assert.Equal(t, true, info.Duration >= 10*time.Millisecond)
+	assert.Equal(t, true, info.At.After(start))
+}
+
+func TestAppConfig_WithLogHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							ctx context.Context,
+							_ middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							switch awsMiddleware.GetOperationName(ctx) {
+							case "StartConfigurationSession":
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.StartConfigurationSessionOutput{
+										InitialConfigurationToken: aws.String("initial-token"),
+									},
+								}, middleware.Metadata{}, nil
+							case "GetLatestConfiguration":
+								return middleware.FinalizeOutput{
+									Result: &appconfigdata.GetLatestConfigurationOutput{
+										Configuration:              []byte(`{"p": {"d": "changed"}}`),
+										NextPollConfigurationToken: aws.String("next-token"),
+									},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	loader := kappconfig.New(
+		"konf", "test", "profiler",
+		kappconfig.WithAWSConfig(cfg), kappconfig.WithPollInterval(20*time.Millisecond),
+		kappconfig.WithLogHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey || attr.Key == "duration" {
+					return slog.Attr{}
+				}
+
+				return attr
+			},
+		})),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, loader.Watch(ctx, func(map[string]any) { cancel() }))
+	assert.Equal(t, "level=DEBUG msg=\"Configuration has been loaded.\" loader=appconfig://konf/profiler\n", buf.String())
+}
+
 func TestAppConfig_String(t *testing.T) {
 	t.Parallel()
 
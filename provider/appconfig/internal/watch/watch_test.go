@@ -0,0 +1,149 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package watch_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf/provider/appconfig/internal/assert"
+	"github.com/nil-go/konf/provider/appconfig/internal/watch"
+)
+
+func TestReporter(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		watch       bool
+		changed     bool
+		err         error
+		status      []bool
+		log         string
+	}{
+		{
+			description: "load",
+			log:         `level=DEBUG msg="Configuration has been loaded." loader=loader`,
+		},
+		{
+			description: "load error",
+			err:         errors.New("load error"),
+			log:         `level=WARN msg="Error when loading configuration." loader=loader error="load error"`,
+		},
+		{
+			description: "watch changed",
+			watch:       true,
+			changed:     true,
+			status:      []bool{true},
+			log:         `level=DEBUG msg="Configuration has been loaded." loader=loader`,
+		},
+		{
+			description: "watch unchanged",
+			watch:       true,
+			status:      []bool{false},
+			log:         `level=DEBUG msg="Configuration is unchanged." loader=loader`,
+		},
+		{
+			description: "watch error",
+			watch:       true,
+			err:         errors.New("watch error"),
+			status:      []bool{false},
+			log:         `level=WARN msg="Error when loading configuration." loader=loader error="watch error"`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				status  []bool
+				details []watch.Status
+				buf     bytes.Buffer
+			)
+			reporter := watch.Reporter{
+				OnStatus: func(changed bool, err error) {
+					assert.Equal(t, testcase.err, err)
+					status = append(status, changed)
+				},
+				OnStatusDetailed: func(s watch.Status) { details = append(details, s) },
+				Logger: slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+					Level: slog.LevelDebug,
+					ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+						if attr.Key == slog.TimeKey || attr.Key == "duration" {
+							return slog.Attr{}
+						}
+
+						return attr
+					},
+				})),
+			}
+			start := time.Now()
+			if testcase.watch {
+				reporter.Watch(context.Background(), loader{}, testcase.changed, testcase.err, start)
+			} else {
+				reporter.Load(context.Background(), loader{}, testcase.err, start)
+			}
+
+			assert.Equal(t, testcase.status, status)
+			assert.Equal(t, 1, len(details))
+			assert.Equal(t, testcase.err, details[0].Err)
+			assert.Equal(t, testcase.err == nil && (!testcase.watch || testcase.changed), details[0].Changed)
+			assert.Equal(t, true, !details[0].At.Before(start))
+			assert.Equal(t, details[0].At.Sub(start), details[0].Duration)
+			assert.Equal(t, testcase.log+"\n", buf.String())
+		})
+	}
+}
+
+func TestReporter_zero(t *testing.T) {
+	t.Parallel()
+
+	var reporter watch.Reporter
+	reporter.Load(context.Background(), loader{}, nil, time.Now())
+	reporter.Watch(context.Background(), loader{}, true, errors.New("watch error"), time.Now())
+}
+
+func TestDebounce(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collapse", func(t *testing.T) {
+		t.Parallel()
+
+		changed := make(chan struct{}, 1)
+		changed <- struct{}{} // The change arriving within the window.
+		start := time.Now()
+		assert.Equal(t, true, watch.Debounce(context.Background(), 10*time.Millisecond, changed))
+		assert.Equal(t, true, time.Since(start) >= 10*time.Millisecond)
+		assert.Equal(t, 0, len(changed))
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		t.Parallel()
+
+		changed := make(chan struct{}, 1)
+		changed <- struct{}{}
+		assert.Equal(t, true, watch.Debounce(context.Background(), 0, changed))
+		assert.Equal(t, 1, len(changed))
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		changed := make(chan struct{}, 1)
+		assert.Equal(t, false, watch.Debounce(ctx, time.Minute, changed))
+	})
+}
+
+type loader struct{}
+
+func (loader) String() string {
+	return "loader"
+}
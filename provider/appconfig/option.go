@@ -4,11 +4,23 @@
 package appconfig
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
+// WithContext provides the context used by Load, which bounds the client setup
+// (e.g. discovering AWS credentials) and loading the configuration.
+//
+// By default, it uses context.Background() which has no deadline.
+func WithContext(ctx context.Context) Option {
+	return func(options *options) {
+		options.ctx = ctx
+	}
+}
+
 // WithAWSConfig provides the AWS Config for the AWS SDK.
 //
 // By default, it loads the default AWS Config.
@@ -20,6 +32,8 @@ func WithAWSConfig(config aws.Config) Option {
 
 // WithPollInterval provides the interval for polling the configuration.
 // The minimum interval required by AWS AppConfig SDK is 15 seconds.
+// If AWS AppConfig requires a longer interval via NextPollIntervalInSeconds,
+// it polls after the required interval instead.
 //
 // The default interval is 1 minute.
 func WithPollInterval(interval time.Duration) Option {
@@ -28,6 +42,16 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.debounce = window
+	}
+}
+
 // WithUnmarshal provides the function used to parses the configuration.
 // The unmarshal function must be able to unmarshal the configuration into a map[string]any.
 //
@@ -38,6 +62,29 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithFeatureFlags parses the configuration as the document of feature flag profile,
+// and surfaces each flag's enabled state plus its attributes under the flag key,
+// e.g. {"myflag": {"enabled": true, "attr": "v"}}. It takes precedence over WithUnmarshal.
+//
+// By default, the configuration is parsed by the function provided by WithUnmarshal.
+func WithFeatureFlags() Option {
+	return func(options *options) {
+		options.featureFlags = true
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a AppConfig with specific options.
 	Option  func(options *options)
@@ -6,6 +6,9 @@
 // It requires following roles to access Azure App Configuration:
 //   - App Configuration Data Reader
 //
+// If Key Vault references are resolved, it also requires following roles on the referenced Key Vault:
+//   - Key Vault Secrets User
+//
 // # Change notification
 //
 // By default, it periodically polls the configuration only.
@@ -35,6 +38,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azappconfig"
 
 	imaps "github.com/nil-go/konf/provider/azappconfig/internal/maps"
+	"github.com/nil-go/konf/provider/azappconfig/internal/watch"
 )
 
 // AppConfig is a Provider that loads configuration from Azure App Configuration.
@@ -43,8 +47,9 @@ import (
 type AppConfig struct {
 	splitter     func(string) []string
 	pollInterval time.Duration
+	debounce     time.Duration
 
-	onStatus  func(bool, error)
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -74,7 +79,10 @@ func (a *AppConfig) Load() (map[string]any, error) {
 		return nil, errNil
 	}
 
-	values, _, err := a.load(context.Background())
+	ctx := context.Background()
+	start := time.Now()
+	values, _, err := a.load(ctx)
+	a.reporter.Load(ctx, a, err, start)
 
 	return values, err
 }
@@ -99,10 +107,12 @@ func (a *AppConfig) Watch(ctx context.Context, onChange func(map[string]any)) er
 		case <-ticker.C:
 			a.changed()
 		case <-a.changedCh:
-			values, changed, err := a.load(ctx)
-			if a.onStatus != nil {
-				a.onStatus(changed, err)
+			if !watch.Debounce(ctx, a.debounce, a.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := a.load(ctx)
+			a.reporter.Watch(ctx, a, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -162,9 +172,19 @@ func (a *AppConfig) OnEvent(event messaging.CloudEvent) error {
 }
 
 func (a *AppConfig) Status(onStatus func(bool, error)) {
-	a.onStatus = onStatus
+	a.reporter.OnStatus = onStatus
+}
+
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (a *AppConfig) StatusDetailed(onStatus func(StatusInfo)) {
+	a.reporter.OnStatusDetailed = onStatus
 }
 
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (a *AppConfig) String() string {
 	return a.client.endpoint
 }
@@ -173,12 +193,22 @@ type clientProxy struct {
 	endpoint    string
 	keyFilter   string
 	labelFilter string
+	labels      []string
+	snapshot    string
 	credential  azcore.TokenCredential
+	keyVault    *keyVault
 
 	client *azappconfig.Client
 
-	timeout   time.Duration
-	lastETags atomic.Pointer[map[string]azcore.ETag]
+	timeout        time.Duration
+	lastETags      atomic.Pointer[map[settingID]azcore.ETag]
+	snapshotLoaded atomic.Bool
+}
+
+// settingID identifies the setting with the index of the label it's listed by.
+type settingID struct {
+	label int
+	key   string
 }
 
 func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error) { //nolint:cyclop,funlen
@@ -196,52 +226,111 @@ func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error)
 		}
 	}
 
-	selector := azappconfig.SettingSelector{
-		Fields: []azappconfig.SettingFields{
-			azappconfig.SettingFieldsKey,
-			azappconfig.SettingFieldsValue,
-			azappconfig.SettingFieldsETag,
-		},
+	if p.snapshot != "" && p.snapshotLoaded.Load() {
+		// The snapshot is immutable, so it never changes after the first load.
+		return nil, false, nil
 	}
-	if p.keyFilter != "" {
-		selector.KeyFilter = &p.keyFilter
+
+	type lister struct {
+		more     func() bool
+		nextPage func(context.Context) ([]azappconfig.Setting, error)
 	}
-	if p.labelFilter != "" {
-		selector.LabelFilter = &p.labelFilter
+	var listers []lister
+	if p.snapshot != "" {
+		pager := p.client.NewListSettingsForSnapshotPager(p.snapshot, nil)
+		listers = append(listers, lister{
+			more: pager.More,
+			nextPage: func(ctx context.Context) ([]azappconfig.Setting, error) {
+				page, err := pager.NextPage(ctx)
+
+				return page.Settings, err //nolint:wrapcheck
+			},
+		})
+	} else {
+		labels := p.labels
+		if len(labels) == 0 {
+			labels = []string{p.labelFilter}
+		}
+		for _, label := range labels {
+			selector := azappconfig.SettingSelector{
+				Fields: []azappconfig.SettingFields{
+					azappconfig.SettingFieldsKey,
+					azappconfig.SettingFieldsValue,
+					azappconfig.SettingFieldsETag,
+					azappconfig.SettingFieldsContentType,
+				},
+			}
+			if p.keyFilter != "" {
+				selector.KeyFilter = &p.keyFilter
+			}
+			if label != "" {
+				selector.LabelFilter = &label
+			}
+			pager := p.client.NewListSettingsPager(selector, nil)
+			listers = append(listers, lister{
+				more: pager.More,
+				nextPage: func(ctx context.Context) ([]azappconfig.Setting, error) {
+					page, err := pager.NextPage(ctx)
+
+					return page.Settings, err //nolint:wrapcheck
+				},
+			})
+		}
 	}
-	pager := p.client.NewListSettingsPager(selector, nil)
 
 	var (
-		values = make(map[string]string)
-		eTags  = make(map[string]azcore.ETag)
+		values     = make(map[string]string)
+		references = make(map[string]string)
+		eTags      = make(map[settingID]azcore.ETag)
 
-		nextPage = func(ctx context.Context) error {
+		loadPage = func(ctx context.Context, label int, nextPage func(context.Context) ([]azappconfig.Setting, error)) error {
 			ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
 			defer cancel()
 
-			page, err := pager.NextPage(ctx)
+			settings, err := nextPage(ctx)
 			if err != nil {
 				return fmt.Errorf("next page of list settings: %w", err)
 			}
 
-			for _, setting := range page.Settings {
-				values[*setting.Key] = *setting.Value
-				eTags[*setting.Key] = *setting.ETag
+			for _, setting := range settings {
+				// The settings of the later label override the settings of the earlier label with the same key.
+				delete(values, *setting.Key)
+				delete(references, *setting.Key)
+				if p.keyVault != nil && setting.ContentType != nil &&
+					*setting.ContentType == keyVaultReferenceContentType {
+					references[*setting.Key] = *setting.Value
+				} else {
+					values[*setting.Key] = *setting.Value
+				}
+				if setting.ETag != nil {
+					eTags[settingID{label: label, key: *setting.Key}] = *setting.ETag
+				}
 			}
 
 			return nil
 		}
 	)
-	for pager.More() {
-		if err := nextPage(ctx); err != nil {
-			return nil, false, err
+	for label, lister := range listers {
+		for lister.more() {
+			if err := loadPage(ctx, label, lister.nextPage); err != nil {
+				return nil, false, err
+			}
 		}
 	}
 
-	if last := p.lastETags.Load(); last != nil && maps.Equal(*last, eTags) {
+	if last := p.lastETags.Load(); p.snapshot == "" && last != nil && maps.Equal(*last, eTags) {
 		return nil, false, nil
 	}
+
+	for key, reference := range references {
+		value, err := p.keyVault.resolve(ctx, reference)
+		if err != nil {
+			return nil, false, fmt.Errorf("resolve key vault reference for %s: %w", key, err)
+		}
+		values[key] = value
+	}
 	p.lastETags.Store(&eTags)
+	p.snapshotLoaded.Store(p.snapshot != "")
 
 	return values, true, nil
 }
@@ -6,16 +6,21 @@ package azappconfig_test
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/messaging"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 
 	"github.com/nil-go/konf/provider/azappconfig"
@@ -88,6 +93,33 @@ func TestAppConfig_Load(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "with labels",
+			opts: []azappconfig.Option{
+				azappconfig.WithLabels("q", "prod"),
+				azappconfig.WithKeySplitter(func(s string) []string { return strings.Split(s, "_") }),
+				azappconfig.WithCredential(nil),
+			},
+			expected: map[string]any{
+				"q": map[string]any{
+					"k": "prod",
+					"d": ".",
+				},
+			},
+		},
+		{
+			description: "with snapshot",
+			opts: []azappconfig.Option{
+				azappconfig.WithSnapshot("snapshot"),
+				azappconfig.WithKeyFilter("p*"),
+				azappconfig.WithCredential(nil),
+			},
+			expected: map[string]any{
+				"s": map[string]any{
+					"k": "v",
+				},
+			},
+		},
 		{
 			description: "with nil splitter",
 			opts: []azappconfig.Option{
@@ -120,12 +152,16 @@ func TestAppConfig_Load(t *testing.T) {
 			defer server.Close()
 
 			loader := azappconfig.New(server.URL, testcase.opts...)
+			var status azappconfig.StatusInfo
+			loader.StatusDetailed(func(info azappconfig.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 				values, err = loader.Load()
 				assert.NoError(t, err)
 				assert.Equal(t, nil, values)
@@ -134,6 +170,105 @@ func TestAppConfig_Load(t *testing.T) {
 	}
 }
 
+//nolint:paralleltest // It sets environment variable for trusting the certificate of key vault server.
+func TestAppConfig_Load_keyVaultReference(t *testing.T) {
+	vault := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/secrets/s/1":
+			_, _ = writer.Write([]byte(`{"value":"secret","id":"` + request.Host + `/secrets/s/1"}`))
+		default:
+			http.Error(writer, `{"error":{"code":"SecretNotFound"}}`, http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+	cert := filepath.Join(t.TempDir(), "cert.pem")
+	assert.NoError(t, os.WriteFile(cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: vault.Certificate().Raw}), 0o600))
+	t.Setenv("SSL_CERT_FILE", cert)
+
+	testcases := []struct {
+		description string
+		uri         string
+		opts        []azappconfig.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "resolve reference",
+			uri:         vault.URL + "/secrets/s/1",
+			opts:        []azappconfig.Option{azappconfig.WithResolveKeyVaultReferences(credential{})},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v",
+					"s": "secret",
+				},
+			},
+		},
+		{
+			description: "unresolved reference",
+			uri:         vault.URL + "/secrets/s/1",
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v",
+					"s": `{"uri":"` + vault.URL + `/secrets/s/1"}`,
+				},
+			},
+		},
+		{
+			description: "secret not found",
+			uri:         vault.URL + "/secrets/unknown",
+			opts:        []azappconfig.Option{azappconfig.WithResolveKeyVaultReferences(credential{})},
+			err:         "resolve key vault reference for p/s: get secret: GET " + vault.URL + "/secrets/unknown/",
+		},
+		{
+			description: "invalid reference",
+			uri:         vault.URL + "/keys/s",
+			opts:        []azappconfig.Option{azappconfig.WithResolveKeyVaultReferences(credential{})},
+			err: "resolve key vault reference for p/s: invalid key vault reference: " +
+				vault.URL + "/keys/s",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+				bytes, _ := json.Marshal(map[string][]map[string]string{
+					"items": {
+						{
+							"key":          "p/s",
+							"value":        `{"uri":"` + testcase.uri + `"}`,
+							"etag":         "ps42",
+							"content_type": "application/vnd.microsoft.appconfig.keyvaultref+json",
+						},
+						{
+							"key":   "p/k",
+							"value": "v",
+							"etag":  "pk42",
+						},
+					},
+				})
+				writer.Header().Set("Sync-Token", "jtqGc1I4=MDoyOA==;sn=28")
+				_, _ = writer.Write(bytes)
+			}))
+			defer server.Close()
+
+			loader := azappconfig.New(server.URL, append(testcase.opts, azappconfig.WithCredential(nil))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.Equal(t, true, err != nil && strings.HasPrefix(err.Error(), testcase.err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+			}
+		})
+	}
+}
+
+type credential struct{}
+
+func (credential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
 func TestAppConfig_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -285,6 +420,26 @@ func httpServer() *httptest.Server {
 		}
 		var items []map[string]string
 		switch {
+		case request.URL.Query().Get("snapshot") != "":
+			items = []map[string]string{
+				{
+					"key":   "s/k",
+					"value": "v",
+				},
+			}
+		case request.URL.Query().Get("label") == "prod":
+			items = []map[string]string{
+				{
+					"key":   "q_k",
+					"value": "prod",
+					"etag":  "qk43",
+				},
+				{
+					"key":   "q_d",
+					"value": ".",
+					"etag":  "qd43",
+				},
+			}
 		case request.URL.Query().Get("label") != "":
 			items = []map[string]string{
 				{
@@ -0,0 +1,74 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package azappconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+const keyVaultReferenceContentType = "application/vnd.microsoft.appconfig.keyvaultref+json"
+
+var errInvalidReference = errors.New("invalid key vault reference")
+
+// keyVault resolves the Key Vault references to the secrets in Azure Key Vault.
+type keyVault struct {
+	credential azcore.TokenCredential
+	clients    map[string]*azsecrets.Client
+}
+
+func (k *keyVault) resolve(ctx context.Context, reference string) (string, error) {
+	var ref struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal([]byte(reference), &ref); err != nil {
+		return "", fmt.Errorf("unmarshal key vault reference: %w", err)
+	}
+	// The uri is in format of https://{vault}/secrets/{name}[/{version}].
+	uri, err := url.Parse(ref.URI)
+	if err != nil {
+		return "", fmt.Errorf("parse key vault reference uri: %w", err)
+	}
+	segments := strings.Split(strings.Trim(uri.Path, "/"), "/")
+	if len(segments) < 2 || len(segments) > 3 || segments[0] != "secrets" {
+		return "", fmt.Errorf("%w: %s", errInvalidReference, ref.URI)
+	}
+	var version string
+	if len(segments) == 3 { //nolint:mnd
+		version = segments[2]
+	}
+
+	vaultURL := uri.Scheme + "://" + uri.Host
+	client, ok := k.clients[vaultURL]
+	if !ok {
+		if client, err = azsecrets.NewClient(vaultURL, k.credential, nil); err != nil {
+			return "", fmt.Errorf("create Azure key vault client: %w", err)
+		}
+		if k.clients == nil {
+			k.clients = make(map[string]*azsecrets.Client)
+		}
+		k.clients[vaultURL] = client
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	resp, err := client.GetSecret(ctx, segments[1], version, nil)
+	if err != nil {
+		return "", fmt.Errorf("get secret: %w", err)
+	}
+	if resp.Value == nil {
+		return "", nil
+	}
+
+	return *resp.Value, nil
+}
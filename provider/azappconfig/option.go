@@ -4,12 +4,14 @@
 package azappconfig
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 )
 
 // WithKeyFilter provides [key filter] that will be used to select a set of configuration setting entities.
+// It's ignored if WithSnapshot is provided.
 //
 // [key filter]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/rest-api-key-value#supported-filters
 func WithKeyFilter(filter string) Option {
@@ -19,6 +21,7 @@ func WithKeyFilter(filter string) Option {
 }
 
 // WithLabelFilter provides [label filter] that will be used to select a set of configuration setting entities.
+// It's ignored if WithSnapshot is provided.
 //
 // [label filter]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/rest-api-key-value#supported-filters
 func WithLabelFilter(filter string) Option {
@@ -27,6 +30,30 @@ func WithLabelFilter(filter string) Option {
 	}
 }
 
+// WithLabels provides the labels that the configuration setting entities are listed with in order,
+// and the settings of the later label override the settings of the earlier label with the same key,
+// e.g. WithLabels("common", "prod") layers the settings of label prod on top of label common.
+// The settings without label can be selected with label `\0`.
+// It takes precedence over WithLabelFilter, and it's ignored if WithSnapshot is provided.
+//
+// It lists settings for each label so it costs one more API call for each extra label.
+func WithLabels(labels ...string) Option {
+	return func(options *options) {
+		options.client.labels = labels
+	}
+}
+
+// WithSnapshot provides the name of [snapshot] that loads configuration setting entities from,
+// which takes precedence over WithKeyFilter and WithLabelFilter.
+// Since the snapshot is immutable, it only loads the configuration once and never reports changes.
+//
+// [snapshot]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/concept-snapshots
+func WithSnapshot(name string) Option {
+	return func(options *options) {
+		options.client.snapshot = name
+	}
+}
+
 // WithCredential provides the azcore.TokenCredential for Azure authentication.
 //
 // By default, it uses azidentity.DefaultAzureCredential.
@@ -36,6 +63,20 @@ func WithCredential(credential azcore.TokenCredential) Option {
 	}
 }
 
+// WithResolveKeyVaultReferences resolves the [Key Vault references] with the given credential,
+// which substitutes the settings with content type application/vnd.microsoft.appconfig.keyvaultref+json
+// with the value of the referenced secret in Azure Key Vault.
+// The secret is only fetched again while the reference setting changes.
+//
+// By default, the Key Vault references are loaded as the raw JSON, e.g. {"uri": "https://vault/secrets/x"}.
+//
+// [Key Vault references]: https://learn.microsoft.com/en-us/azure/azure-app-configuration/use-key-vault-references-dotnet-core
+func WithResolveKeyVaultReferences(credential azcore.TokenCredential) Option {
+	return func(options *options) {
+		options.client.keyVault = &keyVault{credential: credential}
+	}
+}
+
 // WithKeySplitter provides the function used to split setting key into nested path.
 // If it returns an nil/[]string{}/[]string{""}, the variable will be ignored.
 //
@@ -56,6 +97,28 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.debounce = window
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the AppConfig with specific options.
 	Option  func(options *options)
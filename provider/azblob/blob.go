@@ -18,12 +18,14 @@
 package azblob
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -33,6 +35,9 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	"github.com/nil-go/konf/provider/azblob/internal/watch"
 )
 
 // Blob is a Provider that loads configuration from Azure Blob Storage.
@@ -40,9 +45,12 @@ import (
 // To create a new Blob, call [New].
 type Blob struct {
 	pollInterval time.Duration
+	debounce     time.Duration
 	unmarshal    func([]byte, any) error
+	verify       func([]byte) error
+	errorOnEmpty bool
 
-	onStatus  func(bool, error)
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -67,14 +75,20 @@ func New(endpoint, container, blob string, opts ...Option) *Blob {
 	return (*Blob)(option)
 }
 
-var errNil = errors.New("nil Blob")
+var (
+	errNil   = errors.New("nil Blob")
+	errEmpty = errors.New("empty configuration")
+)
 
 func (b *Blob) Load() (map[string]any, error) {
 	if b == nil {
 		return nil, errNil
 	}
 
-	values, _, err := b.load(context.Background())
+	ctx := context.Background()
+	start := time.Now()
+	values, _, err := b.load(ctx)
+	b.reporter.Load(ctx, b, err, start)
 
 	return values, err
 }
@@ -99,10 +113,12 @@ func (b *Blob) Watch(ctx context.Context, onChange func(map[string]any)) error {
 		case <-ticker.C:
 			b.changed()
 		case <-b.changedCh:
-			values, changed, err := b.load(ctx)
-			if b.onStatus != nil {
-				b.onStatus(changed, err)
+			if !watch.Debounce(ctx, b.debounce, b.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := b.load(ctx)
+			b.reporter.Watch(ctx, b, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -121,11 +137,24 @@ func (b *Blob) changed() {
 }
 
 func (b *Blob) load(ctx context.Context) (map[string]any, bool, error) {
-	resp, changed, err := b.client.load(ctx)
+	if b.client.prefix {
+		return b.loadPrefix(ctx)
+	}
+
+	resp, eTag, changed, err := b.client.load(ctx)
 	if !changed || err != nil {
 		return nil, false, err
 	}
 
+	if b.verify != nil {
+		if e := b.verify(resp); e != nil {
+			return nil, false, fmt.Errorf("verify: %w", e)
+		}
+	}
+	if b.errorOnEmpty && len(bytes.TrimSpace(resp)) == 0 {
+		return nil, false, errEmpty
+	}
+
 	unmarshal := b.unmarshal
 	if unmarshal == nil {
 		unmarshal = json.Unmarshal
@@ -134,6 +163,8 @@ func (b *Blob) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	// Store the ETag after the blob is accepted, so the rejected blob is loaded again on next poll.
+	b.client.eTag.Store(eTag)
 
 	return values, true, nil
 }
@@ -156,7 +187,7 @@ func (b *Blob) OnEvent(event messaging.CloudEvent) error {
 		return fmt.Errorf("unmarshal event data: %w", e)
 	}
 
-	if data.URL == b.String() {
+	if b.client.match(data.URL) {
 		if event.Type == "Microsoft.Storage.BlobCreated" {
 			b.changed()
 		}
@@ -168,10 +199,24 @@ func (b *Blob) OnEvent(event messaging.CloudEvent) error {
 }
 
 func (b *Blob) Status(onStatus func(bool, error)) {
-	b.onStatus = onStatus
+	b.reporter.OnStatus = onStatus
+}
+
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (b *Blob) StatusDetailed(onStatus func(StatusInfo)) {
+	b.reporter.OnStatusDetailed = onStatus
 }
 
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (b *Blob) String() string {
+	if b.client.prefix {
+		return b.client.url() + "*"
+	}
+
 	return b.client.url()
 }
 
@@ -179,28 +224,40 @@ type clientProxy struct {
 	endpoint   string
 	container  string
 	blob       string
+	prefix     bool
 	credential azcore.TokenCredential
 
-	client *blob.Client
+	containerClient *container.Client
+	client          *blob.Client
 
-	timeout time.Duration
-	eTag    atomic.Pointer[azcore.ETag]
+	timeout   time.Duration
+	eTag      atomic.Pointer[azcore.ETag]
+	lastETags atomic.Pointer[map[string]azcore.ETag]
 }
 
-func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) { //nolint:cyclop
-	if p.client == nil {
+func (p *clientProxy) ensureClient() error {
+	if p.containerClient == nil {
 		if token, ok := p.credential.(*azidentity.DefaultAzureCredential); ok && reflect.ValueOf(*token).IsZero() {
 			var err error
 			if p.credential, err = azidentity.NewDefaultAzureCredential(nil); err != nil {
-				return nil, false, fmt.Errorf("load default Azure credential: %w", err)
+				return fmt.Errorf("load default Azure credential: %w", err)
 			}
 		}
 
 		client, err := azblob.NewClient(p.endpoint, p.credential, nil)
 		if err != nil {
-			return nil, false, fmt.Errorf("create Azure blob client: %w", err)
+			return fmt.Errorf("create Azure blob client: %w", err)
 		}
-		p.client = client.ServiceClient().NewContainerClient(p.container).NewBlobClient(p.blob)
+		p.containerClient = client.ServiceClient().NewContainerClient(p.container)
+		p.client = p.containerClient.NewBlobClient(p.blob)
+	}
+
+	return nil
+}
+
+func (p *clientProxy) load(ctx context.Context) ([]byte, *azcore.ETag, bool, error) {
+	if err := p.ensureClient(); err != nil {
+		return nil, nil, false, err
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
@@ -214,7 +271,7 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) { //nolint
 		},
 	})
 	if err != nil {
-		return nil, false, fmt.Errorf("get blob: %w", err)
+		return nil, nil, false, fmt.Errorf("get blob: %w", err)
 	}
 	defer func() {
 		// Ignore error: it could do nothing on this error.
@@ -222,19 +279,26 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) { //nolint
 	}()
 
 	if resp.ErrorCode != nil && *resp.ErrorCode == string(bloberror.ConditionNotMet) {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
 	if eTag := p.eTag.Load(); eTag != nil && eTag.Equals(*resp.ETag) {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
-	p.eTag.Store(resp.ETag)
 
 	bytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, false, fmt.Errorf("read blob: %w", err)
+		return nil, nil, false, fmt.Errorf("read blob: %w", err)
+	}
+
+	return bytes, resp.ETag, true, nil
+}
+
+func (p *clientProxy) match(url string) bool {
+	if p.prefix {
+		return strings.HasPrefix(url, p.url())
 	}
 
-	return bytes, true, nil
+	return url == p.url()
 }
 
 func (p *clientProxy) url() string {
@@ -53,7 +53,10 @@ func TestBlob_Load(t *testing.T) {
 
 			loader := azblob.New(server.URL, "container", "blob",
 				append(testcase.opts, azblob.WithUnmarshal(testcase.unmarshal))...)
+			var status azblob.StatusInfo
+			loader.StatusDetailed(func(info azblob.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				if strings.Contains(testcase.err, "%s") {
 					assert.EqualError(t, err, fmt.Sprintf(testcase.err, server.URL))
@@ -62,6 +65,7 @@ func TestBlob_Load(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, true, status.Changed)
 				assert.Equal(t, testcase.expected, values)
 				values, err = loader.Load()
 				assert.NoError(t, err)
@@ -71,6 +75,90 @@ func TestBlob_Load(t *testing.T) {
 	}
 }
 
+func TestBlob_Load_verify(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("Etag", "k42")
+		_, _ = writer.Write([]byte(`{"k":"v"}`))
+	}))
+	defer server.Close()
+
+	var verified atomic.Int32
+	loader := azblob.New(server.URL, "container", "blob",
+		azblob.WithCredential(nil),
+		azblob.WithVerify(func([]byte) error {
+			if verified.Add(1) == 1 {
+				return errors.New("verify error")
+			}
+
+			return nil
+		}),
+	)
+	_, err := loader.Load()
+	assert.EqualError(t, err, "verify: verify error")
+	// The blob which failed verification is loaded again.
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+}
+
+func TestBlob_NewPrefix(t *testing.T) {
+	t.Parallel()
+
+	var eTag atomic.Value
+	eTag.Store("b1")
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch {
+		case request.URL.Query().Get("comp") == "list":
+			assert.Equal(t, "config/", request.URL.Query().Get("prefix"))
+			_, _ = writer.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ContainerName="container">
+  <Prefix>config/</Prefix>
+  <Blobs>
+    <Blob><Name>config/b.json</Name><Properties><Etag>` + eTag.Load().(string) + `</Etag></Properties></Blob>
+    <Blob><Name>config/a.json</Name><Properties><Etag>a</Etag></Properties></Blob>
+  </Blobs>
+  <NextMarker />
+</EnumerationResults>`))
+		case request.URL.Path == "/container/config/a.json":
+			_, _ = writer.Write([]byte(`{"k":"a","a":"a"}`))
+		case request.URL.Path == "/container/config/b.json":
+			_, _ = writer.Write([]byte(`{"k":"` + eTag.Load().(string) + `"}`))
+		default:
+			http.Error(writer, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	loader := azblob.NewPrefix(server.URL, "container", "config/", azblob.WithCredential(nil))
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "b1", "a": "a"}, values)
+
+	// Unchanged since no blob has been changed.
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+
+	eTag.Store("b2")
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "b2", "a": "a"}, values)
+
+	assert.NoError(t, loader.OnEvent(messaging.CloudEvent{
+		Type: "Microsoft.Storage.BlobCreated",
+		Data: []byte(`{"url":"` + server.URL + `/container/config/c.json"}`),
+	}))
+	assert.EqualError(t, loader.OnEvent(messaging.CloudEvent{
+		Type: "Microsoft.Storage.BlobCreated",
+		Data: []byte(`{"url":"` + server.URL + `/container/other/c.json"}`),
+	}), "unsupported blob storage event: unsupported operation")
+}
+
 func TestBlob_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -181,6 +269,30 @@ download blob error
 --------------------------------------------------------------------------------
 `,
 		},
+		{
+			description: "verify error",
+			opts: []azblob.Option{
+				azblob.WithCredential(nil),
+				azblob.WithVerify(func([]byte) error { return errors.New("verify error") }),
+			},
+			handler: func(writer http.ResponseWriter, _ *http.Request) {
+				writer.Header().Set("Etag", "k42")
+				_, _ = writer.Write([]byte(`{"k":"v"}`))
+			},
+			err: "verify: verify error",
+		},
+		{
+			description: "empty error",
+			opts: []azblob.Option{
+				azblob.WithCredential(nil),
+				azblob.WithErrorOnEmpty(),
+			},
+			handler: func(writer http.ResponseWriter, _ *http.Request) {
+				writer.Header().Set("Etag", "k42")
+				_, _ = writer.Write([]byte(" \n"))
+			},
+			err: "empty configuration",
+		},
 		{
 			description: "unmarshal error",
 			opts: []azblob.Option{
@@ -264,4 +376,7 @@ func TestBlob_String(t *testing.T) {
 
 	loader := azblob.New("https://azblob.io", "container", "blob")
 	assert.Equal(t, "https://azblob.io/container/blob", loader.String())
+
+	loader = azblob.NewPrefix("https://azblob.io", "container", "config/")
+	assert.Equal(t, "https://azblob.io/container/config/*", loader.String())
 }
@@ -4,6 +4,7 @@
 package azblob
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -27,6 +28,16 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.debounce = window
+	}
+}
+
 // WithUnmarshal provides the function used to parses the configuration.
 // The unmarshal function must be able to unmarshal the configuration into a map[string]any.
 //
@@ -37,6 +48,40 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithVerify provides the function used to verify the downloaded configuration before unmarshalling,
+// e.g. checking the SHA-256 digest or the detached signature.
+// If it returns an error, the configuration is not applied and the error is reported via Status.
+//
+// By default, it does not verify the configuration.
+func WithVerify(verify func(data []byte) error) Option {
+	return func(options *options) {
+		options.verify = verify
+	}
+}
+
+// WithErrorOnEmpty returns an error if the blob is empty or only contains whitespaces,
+// which guards against applying an empty configuration by the accidental truncation.
+// The error is reported via Status and the configuration is not applied.
+//
+// By default, the empty blob is unmarshalled as is.
+func WithErrorOnEmpty() Option {
+	return func(options *options) {
+		options.errorOnEmpty = true
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the Blob with specific options.
 	Option  func(options *options)
@@ -0,0 +1,144 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package azblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	imaps "github.com/nil-go/konf/provider/azblob/internal/maps"
+)
+
+// NewPrefix creates a Blob with the given endpoint, container, prefix and Option(s),
+// which loads all blobs whose names start with the prefix
+// and merges them in the lexical order of names. The later blob takes precedence.
+func NewPrefix(endpoint, container, prefix string, opts ...Option) *Blob {
+	option := &options{
+		client: clientProxy{
+			// Place holder for the default credential.
+			credential: &azidentity.DefaultAzureCredential{},
+			endpoint:   endpoint,
+			container:  container,
+			blob:       prefix,
+			prefix:     true,
+		},
+		changedCh: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+	option.client.timeout = option.pollInterval / 2 //nolint:mnd
+
+	return (*Blob)(option)
+}
+
+func (b *Blob) loadPrefix(ctx context.Context) (map[string]any, bool, error) {
+	blobs, eTags, changed, err := b.client.loadPrefix(ctx)
+	if !changed || err != nil {
+		return nil, false, err
+	}
+
+	unmarshal := b.unmarshal
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	values := make(map[string]any)
+	for _, blob := range blobs {
+		if b.verify != nil {
+			if e := b.verify(blob); e != nil {
+				return nil, false, fmt.Errorf("verify: %w", e)
+			}
+		}
+		if b.errorOnEmpty && len(bytes.TrimSpace(blob)) == 0 {
+			return nil, false, errEmpty
+		}
+
+		var value map[string]any
+		if e := unmarshal(blob, &value); e != nil {
+			return nil, false, fmt.Errorf("unmarshal: %w", e)
+		}
+		imaps.Merge(values, value)
+	}
+	// Store the ETags after all blobs are accepted, so the rejected blobs are loaded again on next poll.
+	b.client.lastETags.Store(&eTags)
+
+	return values, true, nil
+}
+
+// loadPrefix returns the content of all blobs under the prefix, sorted by names, and their ETags.
+// It reports changed if any blob has been added, removed or modified since last load.
+func (p *clientProxy) loadPrefix(ctx context.Context) ([][]byte, map[string]azcore.ETag, bool, error) {
+	if err := p.ensureClient(); err != nil {
+		return nil, nil, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
+	defer cancel()
+
+	eTags := make(map[string]azcore.ETag)
+	pager := p.containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{Prefix: &p.blob})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("list blobs: %w", err)
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			var eTag azcore.ETag
+			if item.Properties != nil && item.Properties.ETag != nil {
+				eTag = *item.Properties.ETag
+			}
+			eTags[*item.Name] = eTag
+		}
+	}
+	if last := p.lastETags.Load(); last != nil && maps.Equal(*last, eTags) {
+		return nil, nil, false, nil
+	}
+
+	names := make([]string, 0, len(eTags))
+	for name := range eTags {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	blobs := make([][]byte, 0, len(names))
+	for _, name := range names {
+		blob, err := p.readBlob(ctx, name)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		blobs = append(blobs, blob)
+	}
+
+	return blobs, eTags, true, nil
+}
+
+func (p *clientProxy) readBlob(ctx context.Context, name string) ([]byte, error) {
+	resp, err := p.containerClient.NewBlobClient(name).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get blob %s: %w", name, err)
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = resp.Body.Close()
+	}()
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read blob %s: %w", name, err)
+	}
+
+	return bytes, nil
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package dynamodb loads configuration from AWS [DynamoDB].
+//
+// It loads the item with the given key from the table, and returns the attributes
+// except the key and version attributes as a nested map[string]any,
+// e.g. the map (M) attributes are loaded as nested maps and the list (L) attributes are loaded as slices.
+//
+// It requires following permissions to access item from AWS DynamoDB:
+//   - dynamodb:GetItem
+//
+// If change notification with DynamoDB Streams is enabled, it also requires following permissions:
+//   - dynamodb:DescribeTable
+//   - dynamodb:DescribeStream
+//   - dynamodb:GetShardIterator
+//   - dynamodb:GetRecords
+//
+// # Change notification
+//
+// By default, it periodically polls the item, and the change is detected by the version attribute,
+// or the whole item if the item has no version attribute.
+// With WithStreams, it reads the records of the item from [DynamoDB Streams] instead,
+// which must be enabled on the table.
+//
+// [DynamoDB]: https://aws.amazon.com/dynamodb/
+// [DynamoDB Streams]: https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/Streams.html
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+
+	"github.com/nil-go/konf/provider/dynamodb/internal/watch"
+)
+
+// DynamoDB is a Provider that loads configuration from AWS DynamoDB.
+//
+// To create a new DynamoDB, call [New].
+type DynamoDB struct {
+	pollInterval time.Duration
+	streams      bool
+
+	ctx      context.Context //nolint:containedctx
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// New creates a DynamoDB with the given table, key of the item and Option(s).
+func New(table, key string, opts ...Option) *DynamoDB {
+	option := &options{
+		client: clientProxy{
+			table: table,
+			key:   key,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*DynamoDB)(option)
+}
+
+var errNil = errors.New("nil DynamoDB")
+
+func (d *DynamoDB) Load() (map[string]any, error) {
+	if d == nil {
+		return nil, errNil
+	}
+
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	values, _, err := d.client.load(ctx)
+	d.reporter.Load(ctx, d, err, start)
+
+	return values, err
+}
+
+func (d *DynamoDB) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if d == nil {
+		return errNil
+	}
+	if d.streams {
+		return d.watchStream(ctx, onChange)
+	}
+
+	pollInterval := time.Minute
+	if d.pollInterval > 0 {
+		pollInterval = d.pollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.reload(ctx, onChange)
+		}
+	}
+}
+
+func (d *DynamoDB) watchStream(ctx context.Context, onChange func(map[string]any)) error {
+	streamArn, err := d.client.streamArn(ctx)
+	if err != nil {
+		return err
+	}
+
+	// DynamoDB Streams recommends reading the shards once per second.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var (
+		iterators = make(map[string]*string)
+		refresh   = true
+		initial   = true
+	)
+	for {
+		if refresh {
+			// Only read the new records for the existing shards when Watch starts,
+			// and read all records for the shards created afterward, e.g. split from the existing shards.
+			iteratorType := streamtypes.ShardIteratorTypeTrimHorizon
+			if initial {
+				iteratorType = streamtypes.ShardIteratorTypeLatest
+			}
+			if err := d.client.openShards(ctx, streamArn, iterators, iteratorType); err != nil {
+				d.reporter.Watch(ctx, d, false, err, time.Now())
+			} else {
+				refresh, initial = false, false
+			}
+		}
+
+		changed, closed, err := d.client.readShards(ctx, iterators)
+		if err != nil {
+			d.reporter.Watch(ctx, d, false, err, time.Now())
+		}
+		refresh = refresh || closed
+		if changed {
+			d.reload(ctx, onChange)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *DynamoDB) reload(ctx context.Context, onChange func(map[string]any)) {
+	start := time.Now()
+	values, changed, err := d.client.load(ctx)
+	d.reporter.Watch(ctx, d, changed, err, start)
+	if changed {
+		onChange(values)
+	}
+}
+
+func (d *DynamoDB) Status(onStatus func(bool, error)) {
+	d.reporter.OnStatus = onStatus
+}
+
+func (d *DynamoDB) String() string {
+	return "dynamodb://" + d.client.table + "/" + d.client.key
+}
+
+type clientProxy struct {
+	table            string
+	key              string
+	keyAttribute     string
+	versionAttribute string
+	config           aws.Config
+
+	client        *dynamodb.Client
+	streamsClient *dynamodbstreams.Client
+	lastVersion   atomic.Pointer[string]
+	lastItem      atomic.Pointer[map[string]types.AttributeValue]
+}
+
+func (p *clientProxy) load(ctx context.Context) (map[string]any, bool, error) {
+	if err := p.connect(ctx); err != nil {
+		return nil, false, err
+	}
+
+	resp, err := p.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(p.table),
+		Key:            map[string]types.AttributeValue{p.keyAttr(): &types.AttributeValueMemberS{Value: p.key}},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("get dynamodb item: %w", err)
+	}
+
+	item := resp.Item
+	var version string
+	switch value := item[p.versionAttr()].(type) {
+	case *types.AttributeValueMemberN:
+		version = value.Value
+	case *types.AttributeValueMemberS:
+		version = value.Value
+	}
+	if version != "" {
+		if last := p.lastVersion.Load(); last != nil && *last == version {
+			return nil, false, nil
+		}
+		p.lastVersion.Store(&version)
+	} else {
+		if last := p.lastItem.Load(); last != nil && reflect.DeepEqual(*last, item) {
+			return nil, false, nil
+		}
+		p.lastItem.Store(&item)
+	}
+
+	attributes := make(map[string]types.AttributeValue, len(item))
+	for name, value := range item {
+		if name != p.keyAttr() && name != p.versionAttr() {
+			attributes[name] = value
+		}
+	}
+	values := make(map[string]any)
+	if err := attributevalue.UnmarshalMap(attributes, &values); err != nil {
+		return nil, false, fmt.Errorf("unmarshal dynamodb item: %w", err)
+	}
+
+	return values, true, nil
+}
+
+func (p *clientProxy) streamArn(ctx context.Context) (*string, error) {
+	if err := p.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(p.table)})
+	if err != nil {
+		return nil, fmt.Errorf("describe dynamodb table: %w", err)
+	}
+	if resp.Table == nil || resp.Table.LatestStreamArn == nil {
+		return nil, fmt.Errorf("dynamodb streams is not enabled on table %s", p.table) //nolint:err113
+	}
+
+	return resp.Table.LatestStreamArn, nil
+}
+
+// openShards gets the shard iterators for the open shards which are not in the iterators yet.
+func (p *clientProxy) openShards(
+	ctx context.Context,
+	streamArn *string,
+	iterators map[string]*string,
+	iteratorType streamtypes.ShardIteratorType,
+) error {
+	var lastShardID *string
+	for {
+		resp, err := p.streamsClient.DescribeStream(ctx, &dynamodbstreams.DescribeStreamInput{
+			StreamArn:             streamArn,
+			ExclusiveStartShardId: lastShardID,
+		})
+		if err != nil {
+			return fmt.Errorf("describe dynamodb stream: %w", err)
+		}
+		if resp.StreamDescription == nil {
+			return nil
+		}
+
+		for _, shard := range resp.StreamDescription.Shards {
+			shardID := aws.ToString(shard.ShardId)
+			if _, ok := iterators[shardID]; ok ||
+				shard.SequenceNumberRange != nil && shard.SequenceNumberRange.EndingSequenceNumber != nil {
+				continue // Skip the shards which are being read or closed.
+			}
+
+			iterator, err := p.streamsClient.GetShardIterator(ctx, &dynamodbstreams.GetShardIteratorInput{
+				StreamArn:         streamArn,
+				ShardId:           shard.ShardId,
+				ShardIteratorType: iteratorType,
+			})
+			if err != nil {
+				return fmt.Errorf("get dynamodb shard iterator: %w", err)
+			}
+			iterators[shardID] = iterator.ShardIterator
+		}
+
+		if resp.StreamDescription.LastEvaluatedShardId == nil {
+			return nil
+		}
+		lastShardID = resp.StreamDescription.LastEvaluatedShardId
+	}
+}
+
+// readShards reads the records from the shards, and returns whether the item has been changed,
+// and whether any shard has been closed so the new shards need to be opened.
+func (p *clientProxy) readShards(ctx context.Context, iterators map[string]*string) (bool, bool, error) {
+	var (
+		changed, closed bool
+		errs            []error
+	)
+	for shardID, iterator := range iterators {
+		resp, err := p.streamsClient.GetRecords(ctx, &dynamodbstreams.GetRecordsInput{ShardIterator: iterator})
+		if err != nil {
+			// Reopen the shard since the iterator may be expired.
+			delete(iterators, shardID)
+			closed = true
+			errs = append(errs, fmt.Errorf("get dynamodb stream records: %w", err))
+
+			continue
+		}
+
+		for _, record := range resp.Records {
+			if record.Dynamodb == nil {
+				continue
+			}
+			if key, ok := record.Dynamodb.Keys[p.keyAttr()].(*streamtypes.AttributeValueMemberS); ok && key.Value == p.key {
+				changed = true
+			}
+		}
+		if resp.NextShardIterator == nil {
+			delete(iterators, shardID)
+			closed = true
+		} else {
+			iterators[shardID] = resp.NextShardIterator
+		}
+	}
+
+	return changed, closed, errors.Join(errs...)
+}
+
+func (p *clientProxy) connect(ctx context.Context) error {
+	if p.client != nil {
+		return nil
+	}
+
+	if reflect.ValueOf(p.config).IsZero() {
+		var err error
+		if p.config, err = config.LoadDefaultConfig(ctx); err != nil {
+			return fmt.Errorf("load default AWS config: %w", err)
+		}
+	}
+	p.client = dynamodb.NewFromConfig(p.config)
+	p.streamsClient = dynamodbstreams.NewFromConfig(p.config)
+
+	return nil
+}
+
+func (p *clientProxy) keyAttr() string {
+	if p.keyAttribute == "" {
+		return "id"
+	}
+
+	return p.keyAttribute
+}
+
+func (p *clientProxy) versionAttr() string {
+	if p.versionAttribute == "" {
+		return "version"
+	}
+
+	return p.versionAttribute
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package dynamodb_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
+	"github.com/aws/smithy-go/middleware"
+
+	kdynamodb "github.com/nil-go/konf/provider/dynamodb"
+	"github.com/nil-go/konf/provider/dynamodb/internal/assert"
+)
+
+func TestDynamoDB_empty(t *testing.T) {
+	var loader *kdynamodb.DynamoDB
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil DynamoDB")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil DynamoDB")
+}
+
+func TestDynamoDB_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []kdynamodb.Option
+		item        map[string]types.AttributeValue
+		err         error
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			description: "item",
+			item: map[string]types.AttributeValue{
+				"id":      &types.AttributeValueMemberS{Value: "konf"},
+				"version": &types.AttributeValueMemberN{Value: "1"},
+				"p": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+					"k": &types.AttributeValueMemberS{Value: "v"},
+					"n": &types.AttributeValueMemberN{Value: "1.5"},
+					"l": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+						&types.AttributeValueMemberBOOL{Value: true},
+					}},
+				}},
+			},
+			expected: map[string]any{
+				"p": map[string]any{"k": "v", "n": 1.5, "l": []any{true}},
+			},
+		},
+		{
+			description: "with key and version attributes",
+			opts: []kdynamodb.Option{
+				kdynamodb.WithKeyAttribute("service"),
+				kdynamodb.WithVersionAttribute("revision"),
+			},
+			item: map[string]types.AttributeValue{
+				"service":  &types.AttributeValueMemberS{Value: "konf"},
+				"revision": &types.AttributeValueMemberS{Value: "a"},
+				"k":        &types.AttributeValueMemberS{Value: "v"},
+			},
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			description: "item not found",
+			expected:    map[string]any{},
+		},
+		{
+			description: "get item error",
+			err:         errors.New("get item error"),
+			expectedErr: "get dynamodb item: operation error DynamoDB: GetItem, get item error",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := awsConfig(t, func(ctx context.Context) (any, error) {
+				if awsMiddleware.GetOperationName(ctx) != "GetItem" {
+					return nil, errors.New("unexpected operation")
+				}
+
+				return &dynamodb.GetItemOutput{Item: testcase.item}, testcase.err
+			})
+			buf := &bytes.Buffer{}
+			loader := kdynamodb.New("table", "konf",
+				append(testcase.opts, kdynamodb.WithAWSConfig(cfg), kdynamodb.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
+			if testcase.expectedErr != "" {
+				assert.EqualError(t, err, testcase.expectedErr)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.expectedErr)+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestDynamoDB_Watch(t *testing.T) {
+	t.Parallel()
+
+	var version atomic.Int32
+	version.Store(1)
+	cfg := awsConfig(t, func(context.Context) (any, error) {
+		return &dynamodb.GetItemOutput{Item: item(version.Load())}, nil
+	})
+	loader := kdynamodb.New("table", "konf",
+		kdynamodb.WithAWSConfig(cfg),
+		kdynamodb.WithPollInterval(50*time.Millisecond),
+	)
+	var changed atomic.Int32
+	loader.Status(func(c bool, err error) {
+		assert.NoError(t, err)
+		if c {
+			changed.Add(1)
+		}
+	})
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v1"}, values)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan map[string]any)
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(values map[string]any) {
+			changes <- values
+		}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for unchanged polls
+
+	version.Store(2)
+	assert.Equal(t, map[string]any{"k": "v2"}, <-changes)
+	assert.Equal(t, int32(1), changed.Load())
+}
+
+func TestDynamoDB_Watch_streams(t *testing.T) {
+	t.Parallel()
+
+	var (
+		version atomic.Int32
+		reads   atomic.Int32
+	)
+	version.Store(1)
+	cfg := awsConfig(t, func(ctx context.Context) (any, error) {
+		switch awsMiddleware.GetOperationName(ctx) {
+		case "GetItem":
+			return &dynamodb.GetItemOutput{Item: item(version.Load())}, nil
+		case "DescribeTable":
+			return &dynamodb.DescribeTableOutput{
+				Table: &types.TableDescription{LatestStreamArn: aws.String("stream")},
+			}, nil
+		case "DescribeStream":
+			return &dynamodbstreams.DescribeStreamOutput{
+				StreamDescription: &streamtypes.StreamDescription{
+					Shards: []streamtypes.Shard{
+						{ShardId: aws.String("open"), SequenceNumberRange: &streamtypes.SequenceNumberRange{}},
+						{
+							ShardId: aws.String("closed"),
+							SequenceNumberRange: &streamtypes.SequenceNumberRange{
+								EndingSequenceNumber: aws.String("1"),
+							},
+						},
+					},
+				},
+			}, nil
+		case "GetShardIterator":
+			return &dynamodbstreams.GetShardIteratorOutput{ShardIterator: aws.String("iterator")}, nil
+		case "GetRecords":
+			output := &dynamodbstreams.GetRecordsOutput{NextShardIterator: aws.String("iterator")}
+			if reads.Add(1) == 2 {
+				version.Store(2)
+				output.Records = []streamtypes.Record{
+					{Dynamodb: &streamtypes.StreamRecord{Keys: map[string]streamtypes.AttributeValue{
+						"id": &streamtypes.AttributeValueMemberS{Value: "other"},
+					}}},
+					{Dynamodb: &streamtypes.StreamRecord{Keys: map[string]streamtypes.AttributeValue{
+						"id": &streamtypes.AttributeValueMemberS{Value: "konf"},
+					}}},
+				}
+			}
+
+			return output, nil
+		default:
+			return nil, errors.New("unexpected operation")
+		}
+	})
+	loader := kdynamodb.New("table", "konf", kdynamodb.WithAWSConfig(cfg), kdynamodb.WithStreams())
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v1"}, values)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan map[string]any)
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(values map[string]any) {
+			changes <- values
+		}))
+	}()
+	assert.Equal(t, map[string]any{"k": "v2"}, <-changes)
+}
+
+func TestDynamoDB_Watch_streams_disabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := awsConfig(t, func(context.Context) (any, error) {
+		return &dynamodb.DescribeTableOutput{Table: &types.TableDescription{}}, nil
+	})
+	loader := kdynamodb.New("table", "konf", kdynamodb.WithAWSConfig(cfg), kdynamodb.WithStreams())
+	err := loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "dynamodb streams is not enabled on table table")
+}
+
+func TestDynamoDB_String(t *testing.T) {
+	t.Parallel()
+
+	loader := kdynamodb.New("table", "konf")
+	assert.Equal(t, "dynamodb://table/konf", loader.String())
+}
+
+func item(version int32) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "konf"},
+		"version": &types.AttributeValueMemberN{Value: strconv.Itoa(int(version))},
+		"k":       &types.AttributeValueMemberS{Value: "v" + strconv.Itoa(int(version))},
+	}
+}
+
+func awsConfig(t *testing.T, handle func(context.Context) (any, error)) aws.Config {
+	t.Helper()
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithRegion("us-east-1"),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							ctx context.Context,
+							_ middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							result, err := handle(ctx)
+
+							return middleware.FinalizeOutput{Result: result}, middleware.Metadata{}, err
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	return cfg
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
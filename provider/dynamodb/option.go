@@ -0,0 +1,91 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package dynamodb
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// WithKeyAttribute provides the name of the partition key attribute of the table,
+// which must be a string (S) attribute.
+//
+// The default name is "id".
+func WithKeyAttribute(name string) Option {
+	return func(options *options) {
+		options.client.keyAttribute = name
+	}
+}
+
+// WithVersionAttribute provides the name of the version attribute of the item,
+// which is used to detect the change of the item and is not loaded into the configuration.
+// It must be a number (N) or string (S) attribute, and be updated whenever the item is changed.
+//
+// The default name is "version".
+func WithVersionAttribute(name string) Option {
+	return func(options *options) {
+		options.client.versionAttribute = name
+	}
+}
+
+// WithStreams enables watching the changes of the item with DynamoDB Streams
+// rather than polling the item periodically. The stream must be enabled on the table,
+// and the stream view type could be any since it only reads the keys of the records.
+//
+// By default, it polls the item periodically.
+func WithStreams() Option {
+	return func(options *options) {
+		options.streams = true
+	}
+}
+
+// WithPollInterval provides the interval for polling the configuration.
+// It's ignored if WithStreams is provided.
+//
+// The default interval is 1 minute.
+func WithPollInterval(interval time.Duration) Option {
+	return func(options *options) {
+		options.pollInterval = interval
+	}
+}
+
+// WithContext provides the context used by Load, which bounds the client setup
+// (e.g. discovering AWS credentials) and loading the configuration.
+//
+// By default, it uses context.Background() which has no deadline.
+func WithContext(ctx context.Context) Option {
+	return func(options *options) {
+		options.ctx = ctx
+	}
+}
+
+// WithAWSConfig provides the AWS Config for the AWS SDK.
+//
+// By default, it loads the default AWS Config.
+func WithAWSConfig(config aws.Config) Option {
+	return func(options *options) {
+		options.client.config = config
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the a DynamoDB with specific options.
+	Option  func(options *options)
+	options DynamoDB
+)
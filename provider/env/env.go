@@ -9,21 +9,33 @@
 //
 // It splits the names by delimiter. For example, with the default delimiter "_",
 // the environment variable `PARENT_CHILD_KEY="1"` is loaded as `{PARENT: {CHILD: {KEY: "1"}}}`.
+//
+// # Change notification
+//
+// With Env.WithWatchSignal, it reloads environment variables once the process receives the given signal(s),
+// e.g. syscall.SIGHUP sent by a sidecar after rewriting the process environment.
 package env
 
 import (
+	"context"
 	"os"
+	"os/signal"
 	"strings"
+	"time"
 
 	"github.com/nil-go/konf/internal/maps"
+	"github.com/nil-go/konf/internal/watch"
 )
 
 // Env is a Provider that loads configuration from environment variables.
 //
 // To create a new Env, call New.
 type Env struct {
-	prefix   string
-	splitter func(string) []string
+	prefix      string
+	splitter    func(string) []string
+	transformer func(string) []string
+
+	reporter watch.Reporter
 }
 
 // New creates an Env with the given Option(s).
@@ -37,6 +49,14 @@ func New(opts ...Option) Env {
 }
 
 func (e Env) Load() (map[string]any, error) {
+	start := time.Now()
+	values := e.load()
+	e.reporter.Load(context.Background(), e, nil, start)
+
+	return values, nil
+}
+
+func (e Env) load() map[string]any {
 	splitter := e.splitter
 	if splitter == nil {
 		splitter = func(s string) []string { return strings.Split(s, "_") }
@@ -51,15 +71,65 @@ func (e Env) Load() (map[string]any, error) {
 				continue
 			}
 
-			if keys := splitter(key); len(keys) > 1 || len(keys) == 1 && keys[0] != "" {
+			var keys []string
+			if e.transformer != nil {
+				keys = e.transformer(strings.TrimPrefix(key, e.prefix))
+			} else {
+				keys = splitter(key)
+			}
+			if len(keys) > 1 || len(keys) == 1 && keys[0] != "" {
 				maps.Insert(values, keys, value)
 			}
 		}
 	}
 
-	return values, nil
+	return values
 }
 
 func (e Env) String() string {
 	return "env:" + e.prefix + "*"
 }
+
+// WithWatchSignal returns a SignalEnv which works like the Env,
+// but also reloads environment variables once the process receives any of the given signal(s),
+// e.g. syscall.SIGHUP sent by a sidecar after rewriting the process environment.
+func (e Env) WithWatchSignal(signals ...os.Signal) SignalEnv {
+	return SignalEnv{Env: e, signals: signals}
+}
+
+// SignalEnv is a Provider that loads configuration from environment variables,
+// and reloads them once the process receives the signal(s).
+//
+// To create a new SignalEnv, call Env.WithWatchSignal.
+type SignalEnv struct {
+	Env
+
+	signals []os.Signal
+}
+
+// Watch reloads environment variables and calls onChange with the new values
+// each time the process receives any signal provided by Env.WithWatchSignal.
+// If there is no signal provided, it does nothing but blocks until ctx is done.
+func (e SignalEnv) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if len(e.signals) == 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, e.signals...)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			start := time.Now()
+			values := e.load()
+			e.reporter.Watch(ctx, e, true, nil, start)
+			onChange(values)
+		}
+	}
+}
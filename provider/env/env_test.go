@@ -4,15 +4,26 @@
 package env_test
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/nil-go/konf"
 	"github.com/nil-go/konf/internal/assert"
 	"github.com/nil-go/konf/provider/env"
 )
 
-var _ konf.Loader = (*env.Env)(nil)
+var (
+	_ konf.Loader  = (*env.Env)(nil)
+	_ konf.Loader  = (*env.SignalEnv)(nil)
+	_ konf.Watcher = (*env.SignalEnv)(nil)
+)
 
 func TestEnv_empty(t *testing.T) {
 	t.Setenv("P_K", "v")
@@ -67,6 +78,24 @@ func TestEnv_Load(t *testing.T) {
 			},
 			expected: map[string]any{},
 		},
+		{
+			description: "with transformer",
+			opts: []env.Option{
+				env.WithPrefix("P_"),
+				env.WithTransformer(func(name string) []string {
+					if name == "D" {
+						return nil
+					}
+
+					return []string{"p", strings.ToLower(name)}
+				}),
+			},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v",
+				},
+			},
+		},
 	}
 
 	t.Setenv("P_K", "v")
@@ -83,6 +112,65 @@ func TestEnv_Load(t *testing.T) {
 	}
 }
 
+func TestEnv_Load_log(t *testing.T) {
+	t.Setenv("P_K", "v")
+
+	buf := &bytes.Buffer{}
+	values, err := env.New(env.WithPrefix("P_"), env.WithLogHandler(logHandler(buf))).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"P": map[string]any{"K": "v"}}, values)
+	assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+}
+
+func TestEnv_Watch(t *testing.T) {
+	t.Setenv("P_K", "v")
+
+	// Keep the signal from terminating the process before the watch starts listening.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	loader := env.New(env.WithPrefix("P_")).WithWatchSignal(syscall.SIGHUP)
+	values := make(chan map[string]any, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) {
+			select {
+			case values <- changed:
+			default: // Drop the changes from the extra signals.
+			}
+		}))
+	}()
+
+	t.Setenv("P_K", "c")
+	process, err := os.FindProcess(os.Getpid())
+	assert.NoError(t, err)
+	// Send the signal until the watch receives it, since the watch may not listen to the signal yet.
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		assert.NoError(t, process.Signal(syscall.SIGHUP))
+		select {
+		case changed := <-values:
+			assert.Equal(t, map[string]any{"P": map[string]any{"K": "c"}}, changed)
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func TestEnv_Watch_noSignal(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, env.New().WithWatchSignal().Watch(ctx, func(map[string]any) {
+		t.Error("unexpected change")
+	}))
+}
+
 func TestEnv_String(t *testing.T) {
 	t.Parallel()
 
@@ -110,3 +198,16 @@ func TestEnv_String(t *testing.T) {
 		})
 	}
 }
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -3,6 +3,8 @@
 
 package env
 
+import "log/slog"
+
 // WithPrefix provides the prefix used when loading environment variables.
 // Only environment variables with names that start with the prefix will be loaded.
 //
@@ -25,6 +27,31 @@ func WithNameSplitter(splitter func(string) []string) Option {
 	}
 }
 
+// WithTransformer provides the function used to transform environment variable names into nested keys,
+// which receives the name without the prefix provided by WithPrefix.
+// If it returns an nil/[]string{}/[]string{""}, the variable will be ignored.
+// It takes precedence over WithNameSplitter.
+//
+// For example, with prefix "APP_", it could transform an environment variable name like "APP_DB_MAX_CONN"
+// into "db" and "maxConn" by receiving "DB_MAX_CONN".
+func WithTransformer(transformer func(string) []string) Option {
+	return func(options *options) {
+		options.transformer = transformer
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures an Env with specific options.
 	Option  func(*options)
@@ -0,0 +1,221 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package etcd loads configuration from [etcd].
+//
+// Etcd loads all keys under the prefix and returns them as a nested map[string]any.
+// It removes the prefix from the keys and splits them by delimiter. For example, with the default delimiter "/"
+// and the prefix "/app", the key `/app/parent/child/key` is loaded as `{parent: {child: {key: "value"}}}`.
+// The values are raw strings unless the unmarshal function is provided by WithUnmarshal.
+//
+// # Change notification
+//
+// It watches all keys under the prefix with etcd's Watch API,
+// and pushes the latest configuration once any key is put or deleted.
+//
+// [etcd]: https://etcd.io
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/nil-go/konf/provider/etcd/internal/maps"
+	"github.com/nil-go/konf/provider/etcd/internal/watch"
+)
+
+// Etcd is a Provider that loads configuration from etcd.
+//
+// To create a new Etcd, call [New].
+type Etcd struct {
+	splitter  func(string) []string
+	unmarshal func([]byte, any) error
+
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// New creates an Etcd with the given endpoints, prefix and Option(s).
+func New(endpoints []string, prefix string, opts ...Option) *Etcd {
+	option := &options{
+		client: clientProxy{
+			config: clientv3.Config{Endpoints: endpoints},
+			prefix: prefix,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Etcd)(option)
+}
+
+var errNil = errors.New("nil Etcd")
+
+func (e *Etcd) Load() (map[string]any, error) {
+	if e == nil {
+		return nil, errNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	start := time.Now()
+	values, err := e.load(ctx)
+	e.reporter.Load(ctx, e, err, start)
+
+	return values, err
+}
+
+func (e *Etcd) load(ctx context.Context) (map[string]any, error) {
+	entries, revision, err := e.client.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e.client.revision.Store(revision)
+
+	return e.values(entries)
+}
+
+func (e *Etcd) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if e == nil {
+		return errNil
+	}
+
+	entries, revision, err := e.client.load(ctx)
+	if err != nil {
+		return err
+	}
+	changed := revision != e.client.revision.Swap(revision)
+	watchCh := e.client.watch(ctx, revision+1)
+	for {
+		if changed {
+			start := time.Now()
+			values, err := e.values(entries)
+			e.reporter.Watch(ctx, e, err == nil, err, start)
+			if err == nil {
+				onChange(values)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case resp, ok := <-watchCh:
+			if !ok {
+				return nil
+			}
+			if err := resp.Err(); err != nil {
+				e.reporter.Watch(ctx, e, false, fmt.Errorf("watch etcd: %w", err), time.Now())
+				changed = false
+
+				continue
+			}
+
+			for _, event := range resp.Events {
+				switch event.Type {
+				case clientv3.EventTypePut:
+					entries[string(event.Kv.Key)] = event.Kv.Value
+				case clientv3.EventTypeDelete:
+					delete(entries, string(event.Kv.Key))
+				}
+			}
+			e.client.revision.Store(resp.Header.Revision)
+			changed = len(resp.Events) > 0
+		}
+	}
+}
+
+func (e *Etcd) values(entries map[string][]byte) (map[string]any, error) {
+	splitter := e.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(strings.Trim(s, "/"), "/") }
+	}
+
+	values := make(map[string]any)
+	for key, value := range entries {
+		keys := splitter(strings.TrimPrefix(key, e.client.prefix))
+		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
+			continue
+		}
+
+		if e.unmarshal == nil {
+			maps.Insert(values, keys, string(value))
+
+			continue
+		}
+		var val any
+		if err := e.unmarshal(value, &val); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", key, err)
+		}
+		maps.Insert(values, keys, val)
+	}
+
+	return values, nil
+}
+
+func (e *Etcd) Status(onStatus func(bool, error)) {
+	e.reporter.OnStatus = onStatus
+}
+
+func (e *Etcd) String() string {
+	return "etcd://" + strings.Join(e.client.endpoints(), ",") + "/" + strings.TrimPrefix(e.client.prefix, "/")
+}
+
+type clientProxy struct {
+	config clientv3.Config
+	prefix string
+
+	client   *clientv3.Client
+	revision atomic.Int64
+}
+
+func (p *clientProxy) load(ctx context.Context) (map[string][]byte, int64, error) {
+	if err := p.connect(); err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := p.client.Get(ctx, p.prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, fmt.Errorf("get etcd keys: %w", err)
+	}
+
+	entries := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		entries[string(kv.Key)] = kv.Value
+	}
+
+	return entries, resp.Header.Revision, nil
+}
+
+func (p *clientProxy) watch(ctx context.Context, revision int64) clientv3.WatchChan {
+	return p.client.Watch(clientv3.WithRequireLeader(ctx), p.prefix, clientv3.WithPrefix(), clientv3.WithRev(revision))
+}
+
+func (p *clientProxy) connect() error {
+	if p.client != nil {
+		return nil
+	}
+
+	client, err := clientv3.New(p.config)
+	if err != nil {
+		return fmt.Errorf("connect etcd: %w", err)
+	}
+	p.client = client
+
+	return nil
+}
+
+func (p *clientProxy) endpoints() []string {
+	if p.client != nil {
+		return p.client.Endpoints()
+	}
+
+	return p.config.Endpoints
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package etcd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/nil-go/konf/provider/etcd"
+	"github.com/nil-go/konf/provider/etcd/internal/assert"
+)
+
+func TestEtcd_empty(t *testing.T) {
+	var loader *etcd.Etcd
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil Etcd")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil Etcd")
+}
+
+func TestEtcd_Load(t *testing.T) {
+	t.Parallel()
+
+	endpoint, client := startServer(t)
+	_, err := client.Put(context.Background(), "/konf/p/k", "v")
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), "/konf/p/j", `{"a":1}`)
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), "/other/p/k", "o")
+	assert.NoError(t, err)
+
+	testcases := []struct {
+		description string
+		prefix      string
+		opts        []etcd.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "prefix",
+			prefix:      "/konf/",
+			expected:    map[string]any{"p": map[string]any{"k": "v", "j": `{"a":1}`}},
+		},
+		{
+			description: "with name splitter",
+			prefix:      "/konf/",
+			opts: []etcd.Option{
+				etcd.WithNameSplitter(func(s string) []string { return []string{s} }),
+			},
+			expected: map[string]any{"p/k": "v", "p/j": `{"a":1}`},
+		},
+		{
+			description: "with unmarshal",
+			prefix:      "/konf/p/j",
+			opts: []etcd.Option{
+				etcd.WithNameSplitter(func(string) []string { return []string{"j"} }),
+				etcd.WithUnmarshal(json.Unmarshal),
+			},
+			expected: map[string]any{"j": map[string]any{"a": 1.0}},
+		},
+		{
+			description: "unmarshal error",
+			prefix:      "/konf/",
+			opts:        []etcd.Option{etcd.WithUnmarshal(json.Unmarshal)},
+			err:         "unmarshal /konf/p/k: invalid character 'v' looking for beginning of value",
+		},
+		{
+			description: "prefix not found",
+			prefix:      "/not-found/",
+			expected:    map[string]any{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			loader := etcd.New([]string{endpoint}, testcase.prefix, append(testcase.opts, etcd.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.err)+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestEtcd_Watch(t *testing.T) {
+	t.Parallel()
+
+	_, client := startServer(t)
+	_, err := client.Put(context.Background(), "/konf/p/k", "v")
+	assert.NoError(t, err)
+	_, err = client.Put(context.Background(), "/konf/p/d", "d")
+	assert.NoError(t, err)
+
+	loader := etcd.New(nil, "/konf/", etcd.WithClient(client))
+	var changed bool
+	loader.Status(func(c bool, _ error) {
+		changed = c
+	})
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v", "d": "d"}}, values)
+
+	changes := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) {
+			changes <- changed
+		}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	_, err = client.Put(context.Background(), "/konf/p/k", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "c", "d": "d"}}, <-changes)
+	_, err = client.Delete(context.Background(), "/konf/p/d")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "c"}}, <-changes)
+	assert.Equal(t, true, changed)
+}
+
+func TestEtcd_String(t *testing.T) {
+	t.Parallel()
+
+	loader := etcd.New([]string{"localhost:2379", "localhost:2380"}, "/konf")
+	assert.Equal(t, "etcd://localhost:2379,localhost:2380/konf", loader.String())
+}
+
+func startServer(t *testing.T) (string, *clientv3.Client) {
+	t.Helper()
+
+	config := embed.NewConfig()
+	config.Dir = t.TempDir()
+	config.LogLevel = "error"
+	clientURL, peerURL := randomURL(t), randomURL(t)
+	config.ListenClientUrls, config.AdvertiseClientUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	config.ListenPeerUrls, config.AdvertisePeerUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	config.InitialCluster = config.InitialClusterFromName(config.Name)
+
+	server, err := embed.StartEtcd(config)
+	assert.NoError(t, err)
+	t.Cleanup(server.Close)
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("etcd server is not ready")
+	}
+
+	client, err := clientv3.New(clientv3.Config{Endpoints: []string{clientURL.Host}})
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return clientURL.Host, client
+}
+
+func randomURL(t *testing.T) url.URL {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	return url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
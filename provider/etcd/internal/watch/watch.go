@@ -0,0 +1,62 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package watch provides the shared helpers for loading and watching configuration in providers.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Status is the status of a load of the configuration.
+type Status struct {
+	Changed  bool          // Whether the configuration has changed.
+	Err      error         // The error if the load failed.
+	Duration time.Duration // How long the load took.
+	At       time.Time     // When the load completed.
+}
+
+// Reporter reports the status of loads to the status callbacks and the logger.
+// The zero value reports nothing.
+type Reporter struct {
+	OnStatus         func(bool, error)
+	OnStatusDetailed func(Status)
+	Logger           *slog.Logger
+}
+
+// Load reports the status of the load from Loader.Load, which started at the given time.
+// It does not call OnStatus since the error is returned from Loader.Load directly.
+func (r Reporter) Load(ctx context.Context, loader fmt.Stringer, err error, start time.Time) {
+	r.report(ctx, loader, err == nil, err, start)
+}
+
+// Watch reports the status of the load while watching, which started at the given time.
+func (r Reporter) Watch(ctx context.Context, loader fmt.Stringer, changed bool, err error, start time.Time) {
+	if r.OnStatus != nil {
+		r.OnStatus(changed, err)
+	}
+	r.report(ctx, loader, changed, err, start)
+}
+
+func (r Reporter) report(ctx context.Context, loader fmt.Stringer, changed bool, err error, start time.Time) {
+	end := time.Now()
+	if r.OnStatusDetailed != nil {
+		r.OnStatusDetailed(Status{Changed: changed, Err: err, Duration: end.Sub(start), At: end})
+	}
+	if r.Logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("loader", loader.String()), slog.Duration("duration", end.Sub(start))}
+	switch {
+	case err != nil:
+		r.Logger.LogAttrs(ctx, slog.LevelWarn, "Error when loading configuration.", append(attrs, slog.Any("error", err))...)
+	case changed:
+		r.Logger.LogAttrs(ctx, slog.LevelDebug, "Configuration has been loaded.", attrs...)
+	default:
+		r.Logger.LogAttrs(ctx, slog.LevelDebug, "Configuration is unchanged.", attrs...)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package etcd
+
+import (
+	"log/slog"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// WithConfig provides the etcd client config, e.g. TLS and authentication.
+// The endpoints passed to New are used if the config does not have endpoints.
+func WithConfig(config clientv3.Config) Option {
+	return func(options *options) {
+		if len(config.Endpoints) == 0 {
+			config.Endpoints = options.client.config.Endpoints
+		}
+		options.client.config = config
+	}
+}
+
+// WithClient provides the etcd client.
+// It takes precedence over the endpoints passed to New and WithConfig.
+//
+// By default, it connects to the etcd cluster with the given endpoints and config.
+func WithClient(client *clientv3.Client) Option {
+	return func(options *options) {
+		options.client.client = client
+	}
+}
+
+// WithNameSplitter provides the function used to split key names into nested keys.
+// The prefix has been removed from the key names before splitting.
+// If it returns an nil/[]string{}/[]string{""}, the key will be ignored.
+//
+// For example, with the default splitter, a key name like "/parent/child/key"
+// would be split into "parent", "child", and "key".
+func WithNameSplitter(splitter func(string) []string) Option {
+	return func(options *options) {
+		options.splitter = splitter
+	}
+}
+
+// WithUnmarshal provides the function used to parses the value of each key, e.g. json.Unmarshal.
+//
+// By default, the values are loaded as raw strings.
+func WithUnmarshal(unmarshal func([]byte, any) error) Option {
+	return func(options *options) {
+		options.unmarshal = unmarshal
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the Etcd with specific options.
+	Option  func(options *options)
+	options Etcd
+)
@@ -11,11 +11,19 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/nil-go/konf/provider/file/internal/maps"
+	"github.com/nil-go/konf/provider/file/internal/watch"
 )
 
 // File is a Provider that loads configuration from a OS file.
@@ -23,9 +31,12 @@ import (
 // To create a new File, call [New].
 type File struct {
 	path      string
+	override  string
+	glob      bool
+	sort      func(a, b string) int
 	unmarshal func([]byte, any) error
 
-	onStatus func(bool, error)
+	reporter watch.Reporter
 }
 
 // New creates a File with the given path and Option(s).
@@ -40,6 +51,19 @@ func New(path string, opts ...Option) *File {
 	return (*File)(option)
 }
 
+// NewWithOSOverride creates a File with the given base path and Option(s),
+// which overlays the OS-specific file `<base>.<GOOS>.<ext>` on the base file if it exists,
+// e.g. `config.linux.json` for `config.json` on Linux. The OS-specific file takes precedence.
+//
+// It skips the OS-specific file silently if it does not exist.
+func NewWithOSOverride(base string, opts ...Option) *File {
+	file := New(base, opts...)
+	ext := filepath.Ext(base)
+	file.override = strings.TrimSuffix(base, ext) + "." + runtime.GOOS + ext
+
+	return file
+}
+
 var errNil = errors.New("nil File")
 
 func (f *File) Load() (map[string]any, error) {
@@ -47,7 +71,44 @@ func (f *File) Load() (map[string]any, error) {
 		return nil, errNil
 	}
 
-	bytes, err := os.ReadFile(f.path)
+	start := time.Now()
+	values, err := f.loadFiles()
+	f.reporter.Load(context.Background(), f, err, start)
+
+	return values, err
+}
+
+func (f *File) loadFiles() (map[string]any, error) {
+	if f.glob {
+		return f.loadGlob()
+	}
+
+	values, err := f.load(f.path)
+	if err != nil {
+		return nil, err
+	}
+	if f.override == "" {
+		return values, nil
+	}
+
+	override, err := f.load(f.override)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// Skip the override file silently if it does not exist.
+	case err != nil:
+		return nil, err
+	default:
+		if values == nil {
+			values = make(map[string]any)
+		}
+		maps.Merge(values, override)
+	}
+
+	return values, nil
+}
+
+func (f *File) load(path string) (map[string]any, error) {
+	bytes, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read file: %w", err)
 	}
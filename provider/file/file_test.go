@@ -4,11 +4,19 @@
 package file_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/nil-go/konf/provider/file"
 	"github.com/nil-go/konf/provider/file/internal/assert"
 )
@@ -43,6 +51,24 @@ func TestFile_Load(t *testing.T) {
 				"k": "v",
 			},
 		},
+		{
+			description: "yaml with merge keys",
+			path:        "testdata/config.yaml",
+			opts:        []file.Option{file.WithUnmarshal(yaml.Unmarshal)},
+			expected: map[string]any{
+				"base": map[string]any{
+					"host":    "localhost",
+					"port":    5432,
+					"timeout": "5s",
+				},
+				"db": map[string]any{
+					"host":    "localhost",
+					"port":    5433,
+					"timeout": "5s",
+					"name":    "konf",
+				},
+			},
+		},
 		{
 			description: "file (not exist)",
 			path:        "not_found.json",
@@ -75,6 +101,30 @@ func TestFile_Load(t *testing.T) {
 	}
 }
 
+func TestFile_WithLogHandler(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+
+	_, err := file.New("testdata/config.json", file.WithLogHandler(handler)).Load()
+	assert.NoError(t, err)
+	_, err = file.New("testdata/not_found.json", file.WithLogHandler(handler)).Load()
+	assert.EqualError(t, err, "read file: open testdata/not_found.json: no such file or directory")
+	assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."
+level=WARN msg="Error when loading configuration." error="read file: open testdata/not_found.json: no such file or directory"
+`, buf.String())
+}
+
 func TestFile_String(t *testing.T) {
 	t.Parallel()
 
@@ -82,3 +132,87 @@ func TestFile_String(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "file://"+path, file.New("config.json").String())
 }
+
+func TestNewWithOSOverride(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(base, []byte(`{"p": {"k": "v", "o": "base"}}`), 0o600))
+
+	values, err := file.NewWithOSOverride(base).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v", "o": "base"}}, values)
+
+	override := filepath.Join(dir, "config."+runtime.GOOS+".json")
+	assert.NoError(t, os.WriteFile(override, []byte(`{"p": {"o": "`+runtime.GOOS+`"}}`), 0o600))
+	values, err = file.NewWithOSOverride(base).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v", "o": runtime.GOOS}}, values)
+
+	assert.NoError(t, os.WriteFile(override, []byte(`{`), 0o600))
+	_, err = file.NewWithOSOverride(base).Load()
+	assert.EqualError(t, err, "unmarshal: unexpected end of JSON input")
+}
+
+func TestNewGlob(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "9-base.json"), []byte(`{"k": "nine", "b": "base"}`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "10-app.json"), []byte(`{"k": "ten"}`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), []byte(`k: other`), 0o600))
+
+	testcases := []struct {
+		description string
+		pattern     string
+		opts        []file.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "lexical order",
+			pattern:     filepath.Join(dir, "*.json"),
+			expected:    map[string]any{"k": "nine", "b": "base"},
+		},
+		{
+			description: "numeric prefix order",
+			pattern:     filepath.Join(dir, "*.json"),
+			opts: []file.Option{file.WithSort(func(a, b string) int {
+				return numericPrefix(a) - numericPrefix(b)
+			})},
+			expected: map[string]any{"k": "ten", "b": "base"},
+		},
+		{
+			description: "no match",
+			pattern:     filepath.Join(dir, "*.toml"),
+			expected:    map[string]any{},
+		},
+		{
+			description: "bad pattern",
+			pattern:     "[",
+			err:         "glob [: syntax error in pattern",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := file.NewGlob(testcase.pattern, testcase.opts...).Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+			}
+		})
+	}
+}
+
+func numericPrefix(path string) int {
+	prefix, _, _ := strings.Cut(filepath.Base(path), "-")
+	number, _ := strconv.Atoi(prefix)
+
+	return number
+}
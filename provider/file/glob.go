@@ -0,0 +1,109 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/nil-go/konf/provider/file/internal/maps"
+)
+
+// NewGlob creates a File with the given glob pattern and Option(s),
+// which loads all files matching the pattern (see filepath.Match for the syntax)
+// and merges them in the order by file.WithSort. The later file takes precedence.
+// The pattern only supports meta characters in the file name, e.g. `conf.d/*.json`.
+//
+// It returns empty values if there is no file matching the pattern.
+func NewGlob(pattern string, opts ...Option) *File {
+	file := New(pattern, opts...)
+	file.glob = true
+
+	return file
+}
+
+func (f *File) loadGlob() (map[string]any, error) {
+	paths, err := filepath.Glob(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", f.path, err)
+	}
+
+	compare := f.sort
+	if compare == nil {
+		compare = strings.Compare
+	}
+	slices.SortFunc(paths, compare)
+
+	values := make(map[string]any)
+	for _, path := range paths {
+		value, err := f.load(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Merge(values, value)
+	}
+
+	return values, nil
+}
+
+func (f *File) watchGlob(ctx context.Context, onChange func(map[string]any)) (err error) { //nolint:nonamedreturns
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create file watcher for %s: %w", f.path, err)
+	}
+	defer func() {
+		if e := watcher.Close(); e != nil {
+			err = errors.Join(err, e)
+		}
+	}()
+
+	// Watch the parent directory so that the glob is re-evaluated
+	// when a matching file is added or removed.
+	dir, _ := filepath.Split(f.path)
+	if e := watcher.Add(dir); e != nil {
+		return fmt.Errorf("watch dir %s: %w", dir, e)
+	}
+	pattern := filepath.Clean(f.path)
+
+	var (
+		lastEvent     string
+		lastEventTime time.Time
+	)
+	for {
+		select {
+		case event := <-watcher.Events:
+			// Use a simple timer to buffer events as certain events fire
+			// multiple times on some platforms.
+			if event.String() == lastEvent && time.Since(lastEventTime) < 5*time.Millisecond {
+				continue
+			}
+			lastEvent = event.String()
+			lastEventTime = time.Now()
+
+			if matched, _ := filepath.Match(pattern, filepath.Clean(event.Name)); !matched {
+				continue
+			}
+			if event.Has(fsnotify.Create) || event.Has(fsnotify.Write) ||
+				event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				start := time.Now()
+				values, err := f.loadFiles()
+				f.reporter.Watch(ctx, f, true, err, start)
+				onChange(values)
+			}
+
+		case err := <-watcher.Errors:
+			f.reporter.Watch(ctx, f, false, err, time.Now())
+
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -2,6 +2,9 @@ module github.com/nil-go/konf/provider/file
 
 go 1.22
 
-require github.com/fsnotify/fsnotify v1.8.0
+require (
+	github.com/fsnotify/fsnotify v1.8.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require golang.org/x/sys v0.13.0 // indirect
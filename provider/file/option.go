@@ -3,9 +3,14 @@
 
 package file
 
+import "log/slog"
+
 // WithUnmarshal provides the function used to parses the configuration file.
 // The unmarshal function must be able to unmarshal the file content into a map[string]any.
 //
+// For YAML, yaml.Unmarshal from gopkg.in/yaml.v3 expands merge keys (`<<: *anchor`),
+// so the merged values are visible to Config.Unmarshal.
+//
 // The default function is json.Unmarshal.
 func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	return func(options *options) {
@@ -13,6 +18,31 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithSort provides the function to sort the files matching the glob pattern of file.NewGlob,
+// which controls the merge order. The later file takes precedence.
+// It returns a negative number when a < b, a positive number when a > b and zero when a == b.
+//
+// For example, it could sort files by a numeric prefix like `10-` and `20-`.
+//
+// The default function is strings.Compare, which sorts files lexically.
+func WithSort(sort func(a, b string) int) Option {
+	return func(options *options) {
+		options.sort = sort
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a File with specific options.
 	Option  func(options *options)
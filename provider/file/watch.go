@@ -14,14 +14,17 @@ import (
 )
 
 func (f *File) Status(onStatus func(bool, error)) {
-	f.onStatus = onStatus
+	f.reporter.OnStatus = onStatus
 }
 
-//nolint:cyclop,funlen
+//nolint:cyclop,funlen,gocyclo
 func (f *File) Watch(ctx context.Context, onChange func(map[string]any)) (err error) { //nolint:gocognit,nonamedreturns
 	if f == nil {
 		return errNil
 	}
+	if f.glob {
+		return f.watchGlob(ctx, onChange)
+	}
 
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -48,6 +51,22 @@ func (f *File) Watch(ctx context.Context, onChange func(map[string]any)) (err er
 	}
 	realPath = filepath.Clean(realPath)
 
+	// Watch the override file as well, which may not exist yet.
+	var overridePath, overrideRealPath string
+	if f.override != "" {
+		overrideDir, _ := filepath.Split(f.override)
+		if overrideDir != dir {
+			if e := watcher.Add(overrideDir); e != nil {
+				return fmt.Errorf("watch dir %s: %w", overrideDir, e)
+			}
+		}
+		overridePath = filepath.Clean(f.override)
+		overrideRealPath = overridePath
+		if path, e := filepath.EvalSymlinks(f.override); e == nil {
+			overrideRealPath = filepath.Clean(path)
+		}
+	}
+
 	var (
 		lastEvent     string
 		lastEventTime time.Time
@@ -66,28 +85,29 @@ func (f *File) Watch(ctx context.Context, onChange func(map[string]any)) (err er
 			// Since the event is triggered on a directory, is this
 			// one on the file being watched?
 			evFile := filepath.Clean(event.Name)
-			if evFile != realPath && evFile != f.path {
+			isOverride := overridePath != "" && (evFile == overrideRealPath || evFile == overridePath)
+			if evFile != realPath && evFile != f.path && !isOverride {
 				continue
 			}
 
 			switch {
+			case isOverride && (event.Has(fsnotify.Remove) || event.Has(fsnotify.Create) || event.Has(fsnotify.Write)):
+				start := time.Now()
+				values, err := f.loadFiles()
+				f.reporter.Watch(ctx, f, true, err, start)
+				onChange(values)
 			case event.Has(fsnotify.Remove):
-				if f.onStatus != nil {
-					f.onStatus(true, nil)
-				}
+				f.reporter.Watch(ctx, f, true, nil, time.Now())
 				onChange(nil)
 			case event.Has(fsnotify.Create) || event.Has(fsnotify.Write):
-				values, err := f.Load()
-				if f.onStatus != nil {
-					f.onStatus(true, err)
-				}
+				start := time.Now()
+				values, err := f.loadFiles()
+				f.reporter.Watch(ctx, f, true, err, start)
 				onChange(values)
 			}
 
 		case err := <-watcher.Errors:
-			if f.onStatus != nil {
-				f.onStatus(false, err)
-			}
+			f.reporter.Watch(ctx, f, false, err, time.Now())
 
 		case <-ctx.Done():
 			return nil
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"path"
+	"runtime"
 	"testing"
 	"time"
 
@@ -30,6 +31,19 @@ func TestFile_Watch(t *testing.T) {
 			},
 			expected: map[string]any{"p": map[string]any{"k": "c"}},
 		},
+		{
+			description: "atomic rename",
+			action: func(path string) error {
+				// Editors often write a temporary file and then rename it to replace the original file.
+				tmpFile := path + ".tmp"
+				if err := os.WriteFile(tmpFile, []byte(`{"p": {"k": "r"}}`), 0o600); err != nil {
+					return err
+				}
+
+				return os.Rename(tmpFile, path)
+			},
+			expected: map[string]any{"p": map[string]any{"k": "r"}},
+		},
 		{
 			description: "remove",
 			action: func(path string) error {
@@ -72,3 +86,50 @@ func TestFile_Watch(t *testing.T) {
 		})
 	}
 }
+
+func TestFile_Watch_osOverride(t *testing.T) {
+	dir := t.TempDir()
+	base := path.Join(dir, "watch.json")
+	assert.NoError(t, os.WriteFile(base, []byte(`{"p": {"k": "v", "o": "base"}}`), 0o600))
+
+	values := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	loader := file.NewWithOSOverride(base)
+	go func() {
+		err := loader.Watch(ctx, func(changed map[string]any) {
+			values <- changed
+		})
+		assert.NoError(t, err)
+	}()
+	time.Sleep(time.Second) // wait for the watcher to start
+
+	// Write the override file by renaming, so it has the full content once created.
+	tmpFile := path.Join(t.TempDir(), "override.json")
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"p": {"o": "os"}}`), 0o600))
+	assert.NoError(t, os.Rename(tmpFile, path.Join(dir, "watch."+runtime.GOOS+".json")))
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v", "o": "os"}}, <-values)
+}
+
+func TestFile_Watch_glob(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(path.Join(dir, "10-base.json"), []byte(`{"p": {"k": "v", "o": "base"}}`), 0o600))
+
+	values := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	loader := file.NewGlob(path.Join(dir, "*.json"))
+	go func() {
+		err := loader.Watch(ctx, func(changed map[string]any) {
+			values <- changed
+		})
+		assert.NoError(t, err)
+	}()
+	time.Sleep(time.Second) // wait for the watcher to start
+
+	// Write the new file by renaming, so it has the full content once created.
+	tmpFile := path.Join(t.TempDir(), "app.json")
+	assert.NoError(t, os.WriteFile(tmpFile, []byte(`{"p": {"o": "app"}}`), 0o600))
+	assert.NoError(t, os.Rename(tmpFile, path.Join(dir, "20-app.json")))
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v", "o": "app"}}, <-values)
+}
@@ -13,11 +13,14 @@
 package flag
 
 import (
+	"context"
 	"flag"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/nil-go/konf/internal/maps"
+	"github.com/nil-go/konf/internal/watch"
 )
 
 // Flag is a Provider that loads configuration from flags.
@@ -28,6 +31,8 @@ type Flag struct {
 	prefix   string
 	set      *flag.FlagSet
 	splitter func(string) []string
+
+	reporter watch.Reporter
 }
 
 // New creates a Flag with the given Option(s).
@@ -46,7 +51,15 @@ func New(konf konf, opts ...Option) Flag {
 	return Flag(*option)
 }
 
-func (f Flag) Load() (map[string]any, error) { //nolint:cyclop
+func (f Flag) Load() (map[string]any, error) {
+	start := time.Now()
+	values := f.load()
+	f.reporter.Load(context.Background(), f, nil, start)
+
+	return values, nil
+}
+
+func (f Flag) load() map[string]any { //nolint:cyclop
 	set := f.set
 	if set == nil {
 		if !flag.Parsed() {
@@ -95,7 +108,7 @@ func (f Flag) Load() (map[string]any, error) { //nolint:cyclop
 		}
 	})
 
-	return values, nil
+	return values
 }
 
 // isZeroValue is copied from flag/flag.go.
@@ -4,7 +4,9 @@
 package flag_test
 
 import (
+	"bytes"
 	"flag"
+	"log/slog"
 	"strings"
 	"sync"
 	"testing"
@@ -113,6 +115,18 @@ func TestFlag_Load(t *testing.T) {
 	}
 }
 
+func TestFlag_Load_log(t *testing.T) {
+	t.Parallel()
+
+	set := flag.NewFlagSet("set", flag.ContinueOnError)
+	set.String("p.k", "v", "")
+	buf := &bytes.Buffer{}
+	values, err := kflag.New(nil, kflag.WithFlagSet(set), kflag.WithLogHandler(logHandler(buf))).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+	assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+}
+
 func TestFlag_String(t *testing.T) {
 	t.Parallel()
 
@@ -171,3 +185,16 @@ type konfStub struct {
 func (k konfStub) Exists([]string) bool {
 	return k.exists
 }
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -5,6 +5,7 @@ package flag
 
 import (
 	"flag"
+	"log/slog"
 )
 
 // WithPrefix provides the prefix used when loading flags.
@@ -38,6 +39,18 @@ func WithNameSplitter(splitter func(string) []string) Option {
 	}
 }
 
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a Flag with specific options.
 	Option  func(*options)
@@ -11,10 +11,14 @@
 package fs
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
+	"time"
+
+	"github.com/nil-go/konf/internal/watch"
 )
 
 // FS is a Provider that loads configuration from file system.
@@ -24,6 +28,8 @@ type FS struct {
 	fs        fs.FS
 	path      string
 	unmarshal func([]byte, any) error
+
+	reporter watch.Reporter
 }
 
 // New creates a FS with the given fs.FS, path and Option(s).
@@ -43,6 +49,14 @@ func New(fs fs.FS, path string, opts ...Option) FS {
 }
 
 func (f FS) Load() (map[string]any, error) {
+	start := time.Now()
+	values, err := f.load()
+	f.reporter.Load(context.Background(), f, err, start)
+
+	return values, err
+}
+
+func (f FS) load() (map[string]any, error) {
 	ffs := f.fs
 	if ffs == nil {
 		// Ignore error: It uses whatever returned.
@@ -4,8 +4,11 @@
 package fs_test
 
 import (
+	"bytes"
 	"errors"
 	"io/fs"
+	"log/slog"
+	"strconv"
 	"testing"
 	"testing/fstest"
 
@@ -94,12 +97,16 @@ func TestFS_Load(t *testing.T) {
 		t.Run(testcase.description, func(t *testing.T) {
 			t.Parallel()
 
-			values, err := kfs.New(testcase.fs, testcase.path, testcase.opts...).Load()
+			buf := &bytes.Buffer{}
+			loader := kfs.New(testcase.fs, testcase.path, append(testcase.opts, kfs.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.err)+"\n", buf.String())
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
 			}
 		})
 	}
@@ -110,3 +117,16 @@ func TestFS_String(t *testing.T) {
 
 	assert.Equal(t, "fs:///config.json", kfs.New(fstest.MapFS{}, "config.json").String())
 }
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
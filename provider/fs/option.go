@@ -3,6 +3,8 @@
 
 package fs
 
+import "log/slog"
+
 // WithUnmarshal provides the function used to parses the configuration file.
 // The unmarshal function must be able to unmarshal the file content into a map[string]any.
 //
@@ -13,6 +15,18 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a FS with specific options.
 	Option  func(file *options)
@@ -0,0 +1,34 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package gcs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// decompress decompresses the data if it starts with the gzip magic bytes.
+// The object with Content-Encoding: gzip has been decompressed by the storage client.
+func decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = reader.Close()
+	}()
+
+	if data, err = io.ReadAll(reader); err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+
+	return data, nil
+}
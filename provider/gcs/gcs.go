@@ -18,12 +18,14 @@
 package gcs
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -31,6 +33,8 @@ import (
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+
+	"github.com/nil-go/konf/provider/gcs/internal/watch"
 )
 
 // GCS is a Provider that loads configuration from GCP Cloud Storage.
@@ -38,9 +42,12 @@ import (
 // To create a new GCS, call [New].
 type GCS struct {
 	pollInterval time.Duration
+	debounce     time.Duration
 	unmarshal    func([]byte, any) error
+	verify       func([]byte) error
+	errorOnEmpty bool
 
-	onStatus  func(bool, error)
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -70,14 +77,20 @@ func New(uri string, opts ...Option) *GCS {
 	return (*GCS)(option)
 }
 
-var errNil = errors.New("nil GCS")
+var (
+	errNil   = errors.New("nil GCS")
+	errEmpty = errors.New("empty configuration")
+)
 
 func (g *GCS) Load() (map[string]any, error) {
 	if g == nil {
 		return nil, errNil
 	}
 
-	values, _, err := g.load(context.Background())
+	ctx := context.Background()
+	start := time.Now()
+	values, _, err := g.load(ctx)
+	g.reporter.Load(ctx, g, err, start)
 
 	return values, err
 }
@@ -108,10 +121,12 @@ func (g *GCS) Watch(ctx context.Context, onChange func(map[string]any)) error {
 		case <-ticker.C:
 			g.changed()
 		case <-g.changedCh:
-			values, changed, err := g.load(ctx)
-			if g.onStatus != nil {
-				g.onStatus(changed, err)
+			if !watch.Debounce(ctx, g.debounce, g.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := g.load(ctx)
+			g.reporter.Watch(ctx, g, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -130,11 +145,20 @@ func (g *GCS) changed() {
 }
 
 func (g *GCS) load(ctx context.Context) (map[string]any, bool, error) {
-	resp, changed, err := g.client.load(ctx)
+	resp, generation, changed, err := g.client.load(ctx)
 	if !changed || err != nil {
 		return nil, false, err
 	}
 
+	if g.verify != nil {
+		if e := g.verify(resp); e != nil {
+			return nil, false, fmt.Errorf("verify: %w", e)
+		}
+	}
+	if g.errorOnEmpty && len(bytes.TrimSpace(resp)) == 0 {
+		return nil, false, errEmpty
+	}
+
 	unmarshal := g.unmarshal
 	if unmarshal == nil {
 		unmarshal = json.Unmarshal
@@ -144,6 +168,8 @@ func (g *GCS) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	// Store the generation after the object is accepted, so the rejected object is loaded again on next poll.
+	g.client.lastGeneration.Store(generation)
 
 	return values, true, nil
 }
@@ -166,42 +192,67 @@ func (g *GCS) OnEvent(attributes map[string]string) error {
 }
 
 func (g *GCS) Status(onStatus func(bool, error)) {
-	g.onStatus = onStatus
+	g.reporter.OnStatus = onStatus
 }
 
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (g *GCS) StatusDetailed(onStatus func(StatusInfo)) {
+	g.reporter.OnStatusDetailed = onStatus
+}
+
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (g *GCS) String() string {
+	if g.client.generation > 0 {
+		return "gs://" + g.client.bucket + "/" + g.client.object + "#" + strconv.FormatInt(g.client.generation, 10)
+	}
+
 	return "gs://" + g.client.bucket + "/" + g.client.object
 }
 
 type clientProxy struct {
-	bucket string
-	object string
+	bucket     string
+	object     string
+	generation int64
 
 	client         *storage.Client
 	opts           []option.ClientOption
+	decompression  bool
 	lastGeneration atomic.Int64
 }
 
-func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
+func (p *clientProxy) load(ctx context.Context) ([]byte, int64, bool, error) {
 	if p.client == nil {
 		var err error
 		if p.client, err = storage.NewClient(ctx, append(p.opts, storage.WithJSONReads())...); err != nil {
-			return nil, false, fmt.Errorf("create GCS client: %w", err)
+			return nil, 0, false, fmt.Errorf("create GCS client: %w", err)
 		}
 	}
 
 	object := p.client.Bucket(p.bucket).Object(p.object)
-	if generation := p.lastGeneration.Load(); generation > 0 {
+	switch generation := p.lastGeneration.Load(); {
+	case p.generation > 0 && generation == p.generation:
+		// The pinned generation is immutable, so it never changes after the first load.
+		return nil, 0, false, nil
+	case p.generation > 0:
+		object = object.Generation(p.generation)
+	case generation > 0:
 		object = object.If(storage.Conditions{GenerationNotMatch: generation})
 	}
 	reader, err := object.NewReader(ctx)
 	if err != nil {
 		var ge *googleapi.Error
 		if errors.As(err, &ge) && ge.Code == http.StatusNotModified {
-			return nil, false, nil
+			return nil, 0, false, nil
+		}
+		if p.generation > 0 {
+			return nil, 0, false, fmt.Errorf("create object reader of generation %d: %w", p.generation, err)
 		}
 
-		return nil, false, fmt.Errorf("create object reader: %w", err)
+		return nil, 0, false, fmt.Errorf("create object reader: %w", err)
 	}
 	defer func() {
 		// Ignore error: it could do nothing on this error.
@@ -209,14 +260,18 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
 	}()
 
 	if reader.Attrs.Generation == p.lastGeneration.Load() {
-		return nil, false, nil
+		return nil, 0, false, nil
 	}
-	p.lastGeneration.Store(reader.Attrs.Generation)
 
 	bytes, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, false, fmt.Errorf("read object: %w", err)
+		return nil, 0, false, fmt.Errorf("read object: %w", err)
+	}
+	if p.decompression {
+		if bytes, err = decompress(bytes); err != nil {
+			return nil, 0, false, err
+		}
 	}
 
-	return bytes, true, nil
+	return bytes, reader.Attrs.Generation, true, nil
 }
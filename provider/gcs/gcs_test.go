@@ -6,6 +6,8 @@
 package gcs_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -41,27 +43,34 @@ func TestGCS_Load(t *testing.T) {
 
 			loader := gcs.New(
 				"bucket/file",
-				option.WithHTTPClient(&http.Client{
-					Transport: roundTripFunc(func(request *http.Request) *http.Response {
-						assert.Equal(t, "/storage/v1/b/bucket/o/file", request.URL.Path)
-						switch request.URL.Query().Get("alt") {
-						case "media":
-							return testcase.object
-						default:
-							return &http.Response{
-								StatusCode: http.StatusNotFound,
+				append(
+					testcase.opts,
+					option.WithHTTPClient(&http.Client{
+						Transport: roundTripFunc(func(request *http.Request) *http.Response {
+							assert.Equal(t, "/storage/v1/b/bucket/o/file", request.URL.Path)
+							switch request.URL.Query().Get("alt") {
+							case "media":
+								return testcase.object
+							default:
+								return &http.Response{
+									StatusCode: http.StatusNotFound,
+								}
 							}
-						}
+						}),
 					}),
-				}),
-				gcs.WithUnmarshal(testcase.unmarshal),
+					gcs.WithUnmarshal(testcase.unmarshal),
+				)...,
 			)
+			var status gcs.StatusInfo
+			loader.StatusDetailed(func(info gcs.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 				values, err = loader.Load()
 				assert.NoError(t, err)
 				assert.Equal(t, nil, values)
@@ -70,31 +79,131 @@ func TestGCS_Load(t *testing.T) {
 	}
 }
 
-func TestGCS_Watch(t *testing.T) {
+func TestGCS_Load_generation(t *testing.T) {
 	t.Parallel()
 
-	for _, testcase := range append(testcases(), watchcases()...) {
+	testcases := []struct {
+		description string
+		status      int
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "generation",
+			status:      http.StatusOK,
+			expected:    map[string]any{"k": "v"},
+		},
+		{
+			description: "generation not found",
+			status:      http.StatusNotFound,
+			err:         "create object reader of generation 42: storage: object doesn't exist",
+		},
+	}
+
+	for _, testcase := range testcases {
 		t.Run(testcase.description, func(t *testing.T) {
 			t.Parallel()
 
-			var err atomic.Pointer[error]
+			var requests atomic.Int32
 			loader := gcs.New(
 				"bucket/file",
+				gcs.WithGeneration(42),
 				option.WithHTTPClient(&http.Client{
 					Transport: roundTripFunc(func(request *http.Request) *http.Response {
-						assert.Equal(t, "/storage/v1/b/bucket/o/file", request.URL.Path)
-						switch request.URL.Query().Get("alt") {
-						case "media":
-							return testcase.object
-						default:
-							return &http.Response{
-								StatusCode: http.StatusNotFound,
-							}
+						requests.Add(1)
+						assert.Equal(t, "42", request.URL.Query().Get("generation"))
+						assert.Equal(t, "", request.URL.Query().Get("ifGenerationNotMatch"))
+
+						return &http.Response{
+							StatusCode: testcase.status,
+							Body:       io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+							Header:     http.Header{"X-Goog-Generation": []string{"42"}},
 						}
 					}),
 				}),
-				gcs.WithPollInterval(10*time.Millisecond),
-				gcs.WithUnmarshal(testcase.unmarshal),
+			)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, values)
+			values, err = loader.Load()
+			assert.NoError(t, err)
+			assert.Equal(t, nil, values)
+			assert.Equal(t, int32(1), requests.Load())
+		})
+	}
+}
+
+func TestGCS_Load_verify(t *testing.T) {
+	t.Parallel()
+
+	var verified atomic.Int32
+	loader := gcs.New(
+		"bucket/file",
+		gcs.WithVerify(func([]byte) error {
+			if verified.Add(1) == 1 {
+				return errors.New("verify error")
+			}
+
+			return nil
+		}),
+		option.WithHTTPClient(&http.Client{
+			Transport: roundTripFunc(func(request *http.Request) *http.Response {
+				if request.URL.Query().Get("ifGenerationNotMatch") == "42" {
+					return &http.Response{StatusCode: http.StatusNotModified, Body: http.NoBody, Header: make(http.Header)}
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+					Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+				}
+			}),
+		}),
+	)
+	_, err := loader.Load()
+	assert.EqualError(t, err, "verify: verify error")
+	// The object which failed verification is loaded again.
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+}
+
+func TestGCS_Watch(t *testing.T) {
+	t.Parallel()
+
+	for _, testcase := range append(testcases(), watchcases()...) {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var err atomic.Pointer[error]
+			loader := gcs.New(
+				"bucket/file",
+				append(
+					testcase.opts,
+					option.WithHTTPClient(&http.Client{
+						Transport: roundTripFunc(func(request *http.Request) *http.Response {
+							assert.Equal(t, "/storage/v1/b/bucket/o/file", request.URL.Path)
+							switch request.URL.Query().Get("alt") {
+							case "media":
+								return testcase.object
+							default:
+								return &http.Response{
+									StatusCode: http.StatusNotFound,
+								}
+							}
+						}),
+					}),
+					gcs.WithPollInterval(10*time.Millisecond),
+					gcs.WithUnmarshal(testcase.unmarshal),
+				)...,
 			)
 			loader.Status(func(_ bool, e error) {
 				if e != nil {
@@ -145,6 +254,7 @@ type testcase struct {
 	description string
 	object      *http.Response
 	event       map[string]string
+	opts        []gcs.Option
 	unmarshal   func([]byte, any) error
 	expected    map[string]any
 	err         string
@@ -163,6 +273,40 @@ func testcases() []testcase {
 				"k": "v",
 			},
 		},
+		{
+			description: "with decompression",
+			object: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(compress(`{"k": "v"}`))),
+				Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+			},
+			opts: []gcs.Option{gcs.WithDecompression()},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "with decompression (uncompressed)",
+			object: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"k": "v"}`)),
+				Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+			},
+			opts: []gcs.Option{gcs.WithDecompression()},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "corrupt compression",
+			object: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(compress(`{"k": "v"}`)[:20])),
+				Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+			},
+			opts: []gcs.Option{gcs.WithDecompression()},
+			err:  "decompress: unexpected EOF",
+		},
 		{
 			description: "create object reader error",
 			object: &http.Response{
@@ -172,6 +316,26 @@ func testcases() []testcase {
 			},
 			err: "create object reader: storage: object doesn't exist",
 		},
+		{
+			description: "verify error",
+			object: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"k": "v"}`)),
+				Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+			},
+			opts: []gcs.Option{gcs.WithVerify(func([]byte) error { return errors.New("verify error") })},
+			err:  "verify: verify error",
+		},
+		{
+			description: "empty error",
+			object: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(" \n")),
+				Header:     http.Header{"X-Goog-Generation": []string{"42"}},
+			},
+			opts: []gcs.Option{gcs.WithErrorOnEmpty()},
+			err:  "empty configuration",
+		},
 		{
 			description: "unmarshal error",
 			object: &http.Response{
@@ -246,6 +410,17 @@ func TestGCS_String(t *testing.T) {
 
 	loader := gcs.New("gs://bucket/file")
 	assert.Equal(t, "gs://bucket/file", loader.String())
+	loader = gcs.New("gs://bucket/file", gcs.WithGeneration(42))
+	assert.Equal(t, "gs://bucket/file#42", loader.String())
+}
+
+func compress(data string) string {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, _ = writer.Write([]byte(data))
+	_ = writer.Close()
+
+	return buf.String()
 }
 
 type roundTripFunc func(*http.Request) *http.Response
@@ -5,12 +5,26 @@
 package gcs
 
 import (
+	"log/slog"
 	"time"
 
 	"google.golang.org/api/option"
 	"google.golang.org/api/option/internaloption"
 )
 
+// WithGeneration provides the generation of the object to load,
+// which pins the configuration to the explicit generation for reproducible deployment.
+// Since the generation is immutable, it only loads the configuration once and never reports changes.
+//
+// By default, it loads the live generation of the object.
+func WithGeneration(generation int64) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.generation = generation
+		},
+	}
+}
+
 // WithPollInterval provides the interval for polling the configuration.
 //
 // The default interval is 1 minute.
@@ -22,6 +36,18 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.debounce = window
+		},
+	}
+}
+
 // WithUnmarshal provides the function used to parses the configuration.
 // The unmarshal function must be able to unmarshal the configuration into a map[string]any.
 //
@@ -34,6 +60,59 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithVerify provides the function used to verify the downloaded configuration before unmarshalling,
+// e.g. checking the SHA-256 digest or the detached signature.
+// If it returns an error, the configuration is not applied and the error is reported via Status.
+// The data has been decompressed if WithDecompression is provided.
+//
+// By default, it does not verify the configuration.
+func WithVerify(verify func(data []byte) error) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.verify = verify
+		},
+	}
+}
+
+// WithErrorOnEmpty returns an error if the object is empty or only contains whitespaces,
+// which guards against applying an empty configuration by the accidental truncation.
+// The error is reported via Status and the configuration is not applied.
+//
+// By default, the empty object is unmarshalled as is.
+func WithErrorOnEmpty() Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.errorOnEmpty = true
+		},
+	}
+}
+
+// WithDecompression decompresses the gzip-compressed object before unmarshalling,
+// which is detected by the gzip magic bytes.
+//
+// By default, the object is unmarshalled as is.
+func WithDecompression() Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.decompression = true
+		},
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			if handler != nil {
+				options.reporter.Logger = slog.New(handler)
+			}
+		},
+	}
+}
+
 type (
 	Option     = option.ClientOption
 	optionFunc struct {
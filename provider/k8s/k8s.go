@@ -0,0 +1,330 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package k8s loads configuration from Kubernetes [ConfigMap] or [Secret] via Kubernetes API,
+// and watches the changes with the [shared informer].
+//
+// Object loads the data of the ConfigMap or Secret and returns it as a nested map[string]any.
+// It splits the keys by delimiter. For example, with the default delimiter ".",
+// the key `parent.child.key` is loaded as `{parent: {child: {key: "value"}}}`.
+// The base64 encoded data of the Secret is decoded.
+//
+// # Change notification
+//
+// It watches the ConfigMap or Secret with the shared informer of Kubernetes,
+// which re-lists and re-watches the object on failures,
+// and pushes the latest configuration once the object is added or modified.
+// If the object is deleted, it keeps the last configuration and reports the deletion as an error
+// to the callback of Object.Status.
+//
+// [ConfigMap]: https://kubernetes.io/docs/concepts/configuration/configmap/
+// [Secret]: https://kubernetes.io/docs/concepts/configuration/secret/
+// [shared informer]: https://pkg.go.dev/k8s.io/client-go/tools/cache#SharedInformer
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nil-go/konf/provider/k8s/internal/maps"
+	"github.com/nil-go/konf/provider/k8s/internal/watch"
+)
+
+// Object is a Provider that loads configuration from Kubernetes ConfigMap or Secret.
+//
+// To create a new Object, call [NewConfigMap] or [NewSecret].
+type Object struct {
+	splitter func(string) []string
+
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// NewConfigMap creates an Object loading the ConfigMap with the given namespace, name and Option(s).
+// It loads both data and binaryData of the ConfigMap.
+func NewConfigMap(namespace, name string, opts ...Option) *Object {
+	return newObject(namespace, name, configMap, opts)
+}
+
+// NewSecret creates an Object loading the Secret with the given namespace, name and Option(s).
+func NewSecret(namespace, name string, opts ...Option) *Object {
+	return newObject(namespace, name, secret, opts)
+}
+
+func newObject(namespace, name string, kind kind, opts []Option) *Object {
+	option := &options{
+		client: clientProxy{
+			namespace: namespace,
+			name:      name,
+			kind:      kind,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Object)(option)
+}
+
+var errNil = errors.New("nil Object")
+
+func (o *Object) Load() (map[string]any, error) {
+	if o == nil {
+		return nil, errNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	start := time.Now()
+	data, err := o.client.load(ctx)
+	o.reporter.Load(ctx, o, err, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.values(data), nil
+}
+
+func (o *Object) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if o == nil {
+		return errNil
+	}
+
+	informer, err := o.client.informer()
+	if err != nil {
+		return err
+	}
+
+	// The events are sent to the channel so that onChange is executed in the goroutine of Watch.
+	events := make(chan runtime.Object, 1)
+	send := func(object runtime.Object) {
+		select {
+		case events <- object:
+		case <-ctx.Done():
+		}
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if object, ok := obj.(runtime.Object); ok && o.client.matches(object) {
+				send(object)
+			}
+		},
+		UpdateFunc: func(_, obj any) {
+			if object, ok := obj.(runtime.Object); ok && o.client.matches(object) {
+				send(object)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if unknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = unknown.Obj
+			}
+			if object, ok := obj.(runtime.Object); ok && o.client.matches(object) {
+				send(nil)
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("add event handler of %s informer: %w", o.client.kind, err)
+	}
+	if err := informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		o.reporter.Watch(ctx, o, false, fmt.Errorf("watch %s: %w", o.client.kind, err), time.Now())
+	}); err != nil {
+		return fmt.Errorf("set watch error handler of %s informer: %w", o.client.kind, err)
+	}
+	go informer.Run(ctx.Done())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case object := <-events:
+			start := time.Now()
+			if object == nil {
+				// Keep the last values since the deletion is more likely a mistake than an intent to clear
+				// the configuration, and the object is loaded again once it's recreated.
+				o.client.resourceVersion.Store("")
+				o.reporter.Watch(ctx, o, false,
+					fmt.Errorf("%s %s/%s has been deleted", o.client.kind, o.client.namespace, o.client.name), start)
+
+				continue
+			}
+
+			data, resourceVersion := o.client.data(object)
+			if resourceVersion != "" && resourceVersion == o.client.resourceVersion.Load() {
+				// It has been loaded already.
+				continue
+			}
+			o.client.resourceVersion.Store(resourceVersion)
+			values := o.values(data)
+			o.reporter.Watch(ctx, o, true, nil, start)
+			onChange(values)
+		}
+	}
+}
+
+func (o *Object) values(data map[string]string) map[string]any {
+	splitter := o.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(s, ".") }
+	}
+
+	values := make(map[string]any)
+	for key, value := range data {
+		keys := splitter(key)
+		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
+			continue
+		}
+
+		maps.Insert(values, keys, value)
+	}
+
+	return values
+}
+
+func (o *Object) Status(onStatus func(bool, error)) {
+	o.reporter.OnStatus = onStatus
+}
+
+func (o *Object) String() string {
+	return "k8s://" + o.client.kind.String() + "/" + o.client.namespace + "/" + o.client.name
+}
+
+type clientProxy struct {
+	namespace  string
+	name       string
+	kind       kind
+	kubeconfig string
+
+	client          kubernetes.Interface
+	resourceVersion atomic.Value
+}
+
+func (p *clientProxy) load(ctx context.Context) (map[string]string, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var object runtime.Object
+	switch p.kind {
+	case secret:
+		object, err = client.CoreV1().Secrets(p.namespace).Get(ctx, p.name, metav1.GetOptions{})
+	default:
+		object, err = client.CoreV1().ConfigMaps(p.namespace).Get(ctx, p.name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", p.kind, err)
+	}
+	data, resourceVersion := p.data(object)
+	p.resourceVersion.Store(resourceVersion)
+
+	return data, nil
+}
+
+// informer returns the shared informer which only watches the ConfigMap or Secret with the name.
+func (p *clientProxy) informer() (cache.SharedIndexInformer, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(p.namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", p.name).String()
+		}),
+	)
+	switch p.kind {
+	case secret:
+		return factory.Core().V1().Secrets().Informer(), nil
+	default:
+		return factory.Core().V1().ConfigMaps().Informer(), nil
+	}
+}
+
+// matches returns whether the object is the ConfigMap or Secret with the namespace and name.
+func (p *clientProxy) matches(object runtime.Object) bool {
+	accessor, err := meta.Accessor(object)
+
+	return err == nil && accessor.GetNamespace() == p.namespace && accessor.GetName() == p.name
+}
+
+// data returns the data and resource version of the ConfigMap or Secret.
+// The data of Secret has been decoded from base64 by the client.
+func (p *clientProxy) data(object runtime.Object) (map[string]string, string) {
+	data := make(map[string]string)
+	switch object := object.(type) {
+	case *corev1.ConfigMap:
+		for key, value := range object.BinaryData {
+			data[key] = string(value)
+		}
+		for key, value := range object.Data {
+			data[key] = value
+		}
+
+		return data, object.ResourceVersion
+	case *corev1.Secret:
+		for key, value := range object.Data {
+			data[key] = string(value)
+		}
+
+		return data, object.ResourceVersion
+	default:
+		return data, ""
+	}
+}
+
+type kind int
+
+const (
+	configMap kind = iota
+	secret
+)
+
+func (k kind) String() string {
+	switch k {
+	case secret:
+		return "secret"
+	default:
+		return "configmap"
+	}
+}
+
+func (p *clientProxy) getClient() (kubernetes.Interface, error) {
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	var (
+		config *rest.Config
+		err    error
+	)
+	if p.kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", p.kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load kubernetes config: %w", err)
+	}
+	if p.client, err = kubernetes.NewForConfig(config); err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	return p.client, nil
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package k8s_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nil-go/konf/provider/k8s"
+	"github.com/nil-go/konf/provider/k8s/internal/assert"
+)
+
+func TestObject_empty(t *testing.T) {
+	var loader *k8s.Object
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil Object")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil Object")
+}
+
+func TestObject_Load(t *testing.T) {
+	t.Parallel()
+
+	client := fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "konf"},
+			Data:       map[string]string{"p.k": "v"},
+			BinaryData: map[string][]byte{"p.b": []byte("b")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "konf"},
+			Data:       map[string][]byte{"p.password": []byte("secret")},
+		},
+	)
+
+	testcases := []struct {
+		description string
+		name        string
+		loader      func(string, string, ...k8s.Option) *k8s.Object
+		opts        []k8s.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "configmap",
+			name:        "konf",
+			loader:      k8s.NewConfigMap,
+			expected:    map[string]any{"p": map[string]any{"k": "v", "b": "b"}},
+		},
+		{
+			description: "secret",
+			name:        "konf",
+			loader:      k8s.NewSecret,
+			expected:    map[string]any{"p": map[string]any{"password": "secret"}},
+		},
+		{
+			description: "with name splitter",
+			name:        "konf",
+			loader:      k8s.NewConfigMap,
+			opts: []k8s.Option{
+				k8s.WithNameSplitter(func(s string) []string { return []string{s} }),
+			},
+			expected: map[string]any{"p.k": "v", "p.b": "b"},
+		},
+		{
+			description: "configmap not found",
+			name:        "not-found",
+			loader:      k8s.NewConfigMap,
+			err:         `get configmap: configmaps "not-found" not found`,
+		},
+		{
+			description: "secret not found",
+			name:        "not-found",
+			loader:      k8s.NewSecret,
+			err:         `get secret: secrets "not-found" not found`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			loader := testcase.loader("default", testcase.name,
+				append(testcase.opts, k8s.WithClient(client), k8s.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.err)+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestObject_Watch(t *testing.T) {
+	t.Parallel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "konf", ResourceVersion: "1"},
+		Data:       map[string]string{"p.k": "v"},
+	}
+	client := fake.NewSimpleClientset(configMap)
+
+	loader := k8s.NewConfigMap("default", "konf", k8s.WithClient(client))
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+
+	changes := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) {
+			changes <- changed
+		}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	configMap = configMap.DeepCopy()
+	configMap.ResourceVersion = "2"
+	configMap.Data["p.k"] = "c"
+	_, err = client.CoreV1().ConfigMaps("default").Update(context.Background(), configMap, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "c"}}, <-changes)
+}
+
+func TestObject_Watch_secret(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "konf", ResourceVersion: "1"},
+		Data:       map[string][]byte{"p.password": []byte("secret")},
+	}
+	client := fake.NewSimpleClientset(secret)
+
+	loader := k8s.NewSecret("default", "konf", k8s.WithClient(client))
+	errs := make(chan error, 3)
+	loader.Status(func(_ bool, err error) {
+		errs <- err
+	})
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"password": "secret"}}, values)
+
+	changes := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) {
+			changes <- changed
+		}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	// The change of other secrets is ignored.
+	_, err = client.CoreV1().Secrets("default").Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", ResourceVersion: "1"},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	secret = secret.DeepCopy()
+	secret.ResourceVersion = "2"
+	secret.Data["p.password"] = []byte("rotated")
+	_, err = client.CoreV1().Secrets("default").Update(context.Background(), secret, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"password": "rotated"}}, <-changes)
+	assert.NoError(t, <-errs)
+
+	// The deletion is reported without clearing the configuration.
+	err = client.CoreV1().Secrets("default").Delete(context.Background(), "konf", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.EqualError(t, <-errs, "secret default/konf has been deleted")
+
+	// The recreated secret is loaded again.
+	secret = secret.DeepCopy()
+	secret.ResourceVersion = "3"
+	secret.Data["p.password"] = []byte("recreated")
+	_, err = client.CoreV1().Secrets("default").Create(context.Background(), secret, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"password": "recreated"}}, <-changes)
+}
+
+func TestObject_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "k8s://configmap/default/konf", k8s.NewConfigMap("default", "konf").String())
+	assert.Equal(t, "k8s://secret/default/konf", k8s.NewSecret("default", "konf").String())
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package k8sapi loads configuration from Kubernetes [ConfigMap] or [Secret] via Kubernetes API.
+//
+// Object loads the data of the ConfigMap or Secret and returns it as a nested map[string]any.
+// It splits the keys by delimiter. For example, with the default delimiter ".",
+// the key `parent.child.key` is loaded as `{parent: {child: {key: "value"}}}`.
+// The base64 encoded data of the Secret is decoded.
+//
+// # Change notification
+//
+// It watches the ConfigMap or Secret with the Kubernetes watch API,
+// and pushes the latest configuration once the object is added or modified.
+// If the object is deleted, it keeps the last configuration and reports the deletion as an error
+// to the callback of Object.Status.
+//
+// [ConfigMap]: https://kubernetes.io/docs/concepts/configuration/configmap/
+// [Secret]: https://kubernetes.io/docs/concepts/configuration/secret/
+package k8sapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/nil-go/konf/provider/k8sapi/internal/maps"
+	"github.com/nil-go/konf/provider/k8sapi/internal/watch"
+)
+
+// Kind is the kind of Kubernetes object that Object loads configuration from.
+type Kind int
+
+const (
+	// ConfigMap loads configuration from the data and binaryData of Kubernetes ConfigMap.
+	ConfigMap Kind = iota
+	// Secret loads configuration from the data of Kubernetes Secret.
+	Secret
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Secret:
+		return "secret"
+	default:
+		return "configmap"
+	}
+}
+
+// Object is a Provider that loads configuration from Kubernetes ConfigMap or Secret.
+//
+// To create a new Object, call [New].
+type Object struct {
+	splitter func(string) []string
+
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// New creates an Object with the given namespace, name, kind and Option(s).
+func New(namespace, name string, kind Kind, opts ...Option) *Object {
+	option := &options{
+		client: clientProxy{
+			namespace: namespace,
+			name:      name,
+			kind:      kind,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Object)(option)
+}
+
+var errNil = errors.New("nil Object")
+
+func (o *Object) Load() (map[string]any, error) {
+	if o == nil {
+		return nil, errNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	start := time.Now()
+	data, err := o.client.load(ctx)
+	o.reporter.Load(ctx, o, err, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.values(data), nil
+}
+
+func (o *Object) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if o == nil {
+		return errNil
+	}
+
+	watcher, err := o.client.watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			start := time.Now()
+			switch event.Type {
+			case kwatch.Added, kwatch.Modified:
+				data, resourceVersion := o.client.data(event.Object)
+				if resourceVersion != "" && resourceVersion == o.client.resourceVersion.Load() {
+					// It has been loaded already.
+					continue
+				}
+				o.client.resourceVersion.Store(resourceVersion)
+				values := o.values(data)
+				o.reporter.Watch(ctx, o, true, nil, start)
+				onChange(values)
+			case kwatch.Deleted:
+				// Keep the last values since the deletion is more likely a mistake than an intent to clear
+				// the configuration, and the object is loaded again once it's recreated.
+				o.client.resourceVersion.Store("")
+				o.reporter.Watch(ctx, o, false,
+					fmt.Errorf("%s %s/%s has been deleted", o.client.kind, o.client.namespace, o.client.name), start)
+			case kwatch.Error:
+				o.reporter.Watch(ctx, o, false,
+					fmt.Errorf("watch %s: %w", o.client.kind, apierrors.FromObject(event.Object)), start)
+			default:
+				// Ignore bookmark events.
+			}
+		}
+	}
+}
+
+func (o *Object) values(data map[string]string) map[string]any {
+	splitter := o.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(s, ".") }
+	}
+
+	values := make(map[string]any)
+	for key, value := range data {
+		keys := splitter(key)
+		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
+			continue
+		}
+
+		maps.Insert(values, keys, value)
+	}
+
+	return values
+}
+
+func (o *Object) Status(onStatus func(bool, error)) {
+	o.reporter.OnStatus = onStatus
+}
+
+func (o *Object) String() string {
+	return "k8s://" + o.client.kind.String() + "/" + o.client.namespace + "/" + o.client.name
+}
+
+type clientProxy struct {
+	namespace  string
+	name       string
+	kind       Kind
+	kubeconfig string
+
+	client          kubernetes.Interface
+	resourceVersion atomic.Value
+}
+
+func (p *clientProxy) load(ctx context.Context) (map[string]string, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var object runtime.Object
+	switch p.kind {
+	case Secret:
+		object, err = client.CoreV1().Secrets(p.namespace).Get(ctx, p.name, metav1.GetOptions{})
+	default:
+		object, err = client.CoreV1().ConfigMaps(p.namespace).Get(ctx, p.name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", p.kind, err)
+	}
+	data, resourceVersion := p.data(object)
+	p.resourceVersion.Store(resourceVersion)
+
+	return data, nil
+}
+
+func (p *clientProxy) watch(ctx context.Context) (kwatch.Interface, error) {
+	client, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", p.name).String(),
+	}
+	if resourceVersion, ok := p.resourceVersion.Load().(string); ok {
+		opts.ResourceVersion = resourceVersion
+	}
+
+	var watcher kwatch.Interface
+	switch p.kind {
+	case Secret:
+		watcher, err = client.CoreV1().Secrets(p.namespace).Watch(ctx, opts)
+	default:
+		watcher, err = client.CoreV1().ConfigMaps(p.namespace).Watch(ctx, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watch %s: %w", p.kind, err)
+	}
+
+	return watcher, nil
+}
+
+// data returns the data and resource version of the ConfigMap or Secret.
+// The data of Secret has been decoded from base64 by the client.
+func (p *clientProxy) data(object runtime.Object) (map[string]string, string) {
+	data := make(map[string]string)
+	switch object := object.(type) {
+	case *corev1.ConfigMap:
+		for key, value := range object.BinaryData {
+			data[key] = string(value)
+		}
+		for key, value := range object.Data {
+			data[key] = value
+		}
+
+		return data, object.ResourceVersion
+	case *corev1.Secret:
+		for key, value := range object.Data {
+			data[key] = string(value)
+		}
+
+		return data, object.ResourceVersion
+	default:
+		return data, ""
+	}
+}
+
+func (p *clientProxy) getClient() (kubernetes.Interface, error) {
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	var (
+		config *rest.Config
+		err    error
+	)
+	if p.kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", p.kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load kubernetes config: %w", err)
+	}
+	if p.client, err = kubernetes.NewForConfig(config); err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+
+	return p.client, nil
+}
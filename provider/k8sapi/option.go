@@ -0,0 +1,59 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package k8sapi
+
+import (
+	"log/slog"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// WithKubeconfig provides the path of kubeconfig file for authentication,
+// which is used while running outside the Kubernetes cluster.
+//
+// By default, it uses the in-cluster config from the service account of the pod.
+func WithKubeconfig(path string) Option {
+	return func(options *options) {
+		options.client.kubeconfig = path
+	}
+}
+
+// WithClient provides the Kubernetes client.
+// It takes precedence over WithKubeconfig.
+//
+// By default, it creates a new client with the in-cluster config or the kubeconfig file.
+func WithClient(client kubernetes.Interface) Option {
+	return func(options *options) {
+		options.client.client = client
+	}
+}
+
+// WithNameSplitter provides the function used to split key names into nested keys.
+// If it returns an nil/[]string{}/[]string{""}, the key will be ignored.
+//
+// For example, with the default splitter, a key name like "parent.child.key"
+// would be split into "parent", "child", and "key".
+func WithNameSplitter(splitter func(string) []string) Option {
+	return func(options *options) {
+		options.splitter = splitter
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the an Object with specific options.
+	Option  func(options *options)
+	options Object
+)
@@ -0,0 +1,260 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package natskv loads configuration from [NATS] JetStream Key/Value bucket.
+//
+// KV loads all keys in the bucket and returns them as a nested map[string]any.
+// It splits the keys by delimiter. For example, with the default delimiter ".",
+// the key `parent.child.key` is loaded as `{parent: {child: {key: "value"}}}`.
+//
+// # Change notification
+//
+// It watches all keys in the bucket with the bucket's WatchAll API,
+// and pushes the latest configuration once any key is put, deleted or purged.
+//
+// [NATS]: https://docs.nats.io/nats-concepts/jetstream/key-value-store
+package natskv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/nil-go/konf/provider/natskv/internal/maps"
+	"github.com/nil-go/konf/provider/natskv/internal/watch"
+)
+
+// KV is a Provider that loads configuration from NATS JetStream Key/Value bucket.
+//
+// To create a new KV, call [New].
+type KV struct {
+	splitter func(string) []string
+
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// New creates a KV with the given bucket and Option(s).
+func New(bucket string, opts ...Option) *KV {
+	option := &options{
+		client: clientProxy{
+			bucket: bucket,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*KV)(option)
+}
+
+var errNil = errors.New("nil KV")
+
+func (k *KV) Load() (map[string]any, error) {
+	if k == nil {
+		return nil, errNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	start := time.Now()
+	entries, err := k.client.load(ctx)
+	k.reporter.Load(ctx, k, err, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.values(entries), nil
+}
+
+func (k *KV) Watch(ctx context.Context, onChange func(map[string]any)) error { //nolint:cyclop
+	if k == nil {
+		return errNil
+	}
+
+	defer k.client.close()
+
+	watcher, err := k.client.watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = watcher.Stop()
+	}()
+
+	var (
+		entries  = make(map[string]string)
+		revision uint64
+		initial  = true
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return nil
+			}
+			start := time.Now()
+
+			// The nil entry marks all initial values have been received.
+			if entry == nil {
+				initial = false
+				if revision == k.client.revision.Load() {
+					continue
+				}
+			} else {
+				revision = max(revision, entry.Revision())
+				switch entry.Operation() {
+				case jetstream.KeyValuePut:
+					entries[entry.Key()] = string(entry.Value())
+				default:
+					delete(entries, entry.Key())
+				}
+				if initial {
+					continue
+				}
+			}
+			k.client.revision.Store(revision)
+
+			values := k.values(entries)
+			k.reporter.Watch(ctx, k, true, nil, start)
+			onChange(values)
+		}
+	}
+}
+
+func (k *KV) values(entries map[string]string) map[string]any {
+	splitter := k.splitter
+	if splitter == nil {
+		splitter = func(s string) []string { return strings.Split(s, ".") }
+	}
+
+	values := make(map[string]any)
+	for key, value := range entries {
+		keys := splitter(key)
+		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
+			continue
+		}
+
+		maps.Insert(values, keys, value)
+	}
+
+	return values
+}
+
+func (k *KV) Status(onStatus func(bool, error)) {
+	k.reporter.OnStatus = onStatus
+}
+
+func (k *KV) String() string {
+	return "nats-kv://" + k.client.bucket
+}
+
+type clientProxy struct {
+	url     string
+	options []nats.Option
+	bucket  string
+
+	mutex    sync.Mutex
+	conn     *nats.Conn
+	ownConn  bool
+	kv       jetstream.KeyValue
+	revision atomic.Uint64
+}
+
+func (p *clientProxy) load(ctx context.Context) (map[string]string, error) {
+	watcher, err := p.watch(ctx, jetstream.IgnoreDeletes())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = watcher.Stop()
+	}()
+
+	var revision uint64
+	entries := make(map[string]string)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("read nats kv: %w", ctx.Err())
+		case entry := <-watcher.Updates():
+			// The nil entry marks all initial values have been received.
+			if entry == nil {
+				p.revision.Store(revision)
+
+				return entries, nil
+			}
+			revision = max(revision, entry.Revision())
+			entries[entry.Key()] = string(entry.Value())
+		}
+	}
+}
+
+func (p *clientProxy) watch(ctx context.Context, opts ...jetstream.WatchOpt) (jetstream.KeyWatcher, error) {
+	kv, err := p.keyValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher, err := kv.WatchAll(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("read nats kv: %w", err)
+	}
+
+	return watcher, nil
+}
+
+func (p *clientProxy) keyValue(ctx context.Context) (jetstream.KeyValue, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.kv != nil {
+		return p.kv, nil
+	}
+
+	if p.conn == nil {
+		url := p.url
+		if url == "" {
+			url = nats.DefaultURL
+		}
+		conn, err := nats.Connect(url, p.options...)
+		if err != nil {
+			return nil, fmt.Errorf("connect nats: %w", err)
+		}
+		p.conn, p.ownConn = conn, true
+	}
+
+	stream, err := jetstream.New(p.conn)
+	if err != nil {
+		return nil, fmt.Errorf("create jetstream: %w", err)
+	}
+	if p.kv, err = stream.KeyValue(ctx, p.bucket); err != nil {
+		return nil, fmt.Errorf("read nats kv: %w", err)
+	}
+
+	return p.kv, nil
+}
+
+// close closes the connection if it's created by the provider.
+func (p *clientProxy) close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.ownConn {
+		return
+	}
+
+	p.conn.Close()
+	p.conn, p.ownConn, p.kv = nil, false, nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package natskv_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/nil-go/konf/provider/natskv"
+	"github.com/nil-go/konf/provider/natskv/internal/assert"
+)
+
+func TestKV_empty(t *testing.T) {
+	var loader *natskv.KV
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil KV")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil KV")
+}
+
+func TestKV_Load(t *testing.T) {
+	t.Parallel()
+
+	url, kv := startServer(t)
+	_, err := kv.Put(context.Background(), "p.k", []byte("v"))
+	assert.NoError(t, err)
+	_, err = kv.Put(context.Background(), "p.d", []byte("d"))
+	assert.NoError(t, err)
+	assert.NoError(t, kv.Delete(context.Background(), "p.d"))
+
+	testcases := []struct {
+		description string
+		bucket      string
+		opts        []natskv.Option
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "bucket",
+			bucket:      "konf",
+			expected:    map[string]any{"p": map[string]any{"k": "v"}},
+		},
+		{
+			description: "with name splitter",
+			bucket:      "konf",
+			opts: []natskv.Option{
+				natskv.WithNameSplitter(func(s string) []string { return []string{s} }),
+			},
+			expected: map[string]any{"p.k": "v"},
+		},
+		{
+			description: "bucket not found",
+			bucket:      "not-found",
+			err:         "read nats kv: nats: bucket not found",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			loader := natskv.New(testcase.bucket,
+				append(testcase.opts, natskv.WithURL(url), natskv.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error="`+testcase.err+`"`+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestKV_Watch(t *testing.T) {
+	t.Parallel()
+
+	url, kv := startServer(t)
+	_, err := kv.Put(context.Background(), "p.k", []byte("v"))
+	assert.NoError(t, err)
+
+	loader := natskv.New("konf", natskv.WithURL(url))
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+
+	changes := make(chan map[string]any)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		assert.NoError(t, loader.Watch(ctx, func(changed map[string]any) {
+			changes <- changed
+		}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	_, err = kv.Put(context.Background(), "p.k", []byte("c"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "c"}}, <-changes)
+}
+
+func TestKV_Watch_close(t *testing.T) {
+	t.Parallel()
+
+	url, kv := startServer(t)
+	_, err := kv.Put(context.Background(), "p.k", []byte("v"))
+	assert.NoError(t, err)
+
+	closed := make(chan struct{})
+	loader := natskv.New("konf",
+		natskv.WithURL(url),
+		natskv.WithConnOptions(nats.ClosedHandler(func(*nats.Conn) { close(closed) })),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, loader.Watch(ctx, func(map[string]any) {}))
+	}()
+	// Load concurrently while Watch creates the connection.
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+
+	cancel()
+	<-stopped
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("the connection created by the provider is not closed")
+	}
+}
+
+func TestKV_Watch_conn(t *testing.T) {
+	t.Parallel()
+
+	url, _ := startServer(t)
+	conn, err := nats.Connect(url)
+	assert.NoError(t, err)
+	t.Cleanup(conn.Close)
+
+	loader := natskv.New("konf", natskv.WithConn(conn))
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, loader.Watch(ctx, func(map[string]any) {}))
+	}()
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	cancel()
+	<-stopped
+	// The connection provided by WithConn is not closed.
+	assert.True(t, !conn.IsClosed())
+}
+
+func startServer(t *testing.T) (string, jetstream.KeyValue) {
+	t.Helper()
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	assert.NoError(t, err)
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	assert.True(t, srv.ReadyForConnections(10*time.Second))
+
+	conn, err := nats.Connect(srv.ClientURL())
+	assert.NoError(t, err)
+	t.Cleanup(conn.Close)
+	stream, err := jetstream.New(conn)
+	assert.NoError(t, err)
+	kv, err := stream.CreateKeyValue(context.Background(), jetstream.KeyValueConfig{Bucket: "konf"})
+	assert.NoError(t, err)
+
+	return srv.ClientURL(), kv
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package natskv
+
+import (
+	"log/slog"
+
+	"github.com/nats-io/nats.go"
+)
+
+// WithURL provides the URL of NATS server(s), separated by comma.
+//
+// By default, it connects to nats.DefaultURL.
+func WithURL(url string) Option {
+	return func(options *options) {
+		options.client.url = url
+	}
+}
+
+// WithConnOptions provides the nats.Option(s) used while connecting to the NATS server,
+// e.g. nats.UserInfo, nats.Token or nats.UserCredentials for authentication.
+func WithConnOptions(opts ...nats.Option) Option {
+	return func(options *options) {
+		options.client.options = append(options.client.options, opts...)
+	}
+}
+
+// WithConn provides the NATS connection, which is not closed by the KV.
+// It takes precedence over WithURL and WithConnOptions.
+//
+// By default, it connects to the NATS server with the given URL and nats.Option(s),
+// and closes the connection when KV.Watch returns.
+func WithConn(conn *nats.Conn) Option {
+	return func(options *options) {
+		options.client.conn = conn
+	}
+}
+
+// WithNameSplitter provides the function used to split key names into nested keys.
+// If it returns an nil/[]string{}/[]string{""}, the key will be ignored.
+//
+// For example, with the default splitter, a key name like "parent.child.key"
+// would be split into "parent", "child", and "key".
+func WithNameSplitter(splitter func(string) []string) Option {
+	return func(options *options) {
+		options.splitter = splitter
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the a KV with specific options.
+	Option  func(options *options)
+	options KV
+)
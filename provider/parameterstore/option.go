@@ -4,6 +4,8 @@
 package parameterstore
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -34,6 +36,17 @@ func WithFilter(filters ...types.ParameterStringFilter) Option {
 	}
 }
 
+// WithDecryption provides whether decrypting the values of SecureString parameters,
+// which requires the permission to decrypt with the KMS key.
+// If it's disabled, the encrypted ciphertext is returned for SecureString parameters.
+//
+// By default, it decrypts the values of SecureString parameters.
+func WithDecryption(decryption bool) Option {
+	return func(options *options) {
+		options.client.withoutDecryption = !decryption
+	}
+}
+
 // WithNameSplitter provides the function used to split parameter names into nested keys.
 // If it returns an nil/[]string{}/[]string{""}, the parameter will be ignored.
 //
@@ -45,6 +58,17 @@ func WithNameSplitter(splitter func(string) []string) Option {
 	}
 }
 
+// WithTrimPrefix provides the prefix that is removed from parameter names before splitting.
+// The parameters without the prefix are left untouched.
+//
+// For example, with prefix "/prod", the parameter name "/prod/app/db/host"
+// would be loaded as "app", "db", and "host" with the default splitter.
+func WithTrimPrefix(prefix string) Option {
+	return func(options *options) {
+		options.trimPrefix = prefix
+	}
+}
+
 // WithPollInterval provides the interval for polling the configuration.
 //
 // The default interval is 1 minute.
@@ -54,6 +78,26 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.debounce = window
+	}
+}
+
+// WithContext provides the context used by Load, which bounds the client setup
+// (e.g. discovering AWS credentials) and loading the configuration.
+//
+// By default, it uses context.Background() which has no deadline.
+func WithContext(ctx context.Context) Option {
+	return func(options *options) {
+		options.ctx = ctx
+	}
+}
+
 // WithAWSConfig provides the AWS Config for the AWS SDK.
 //
 // By default, it loads the default AWS Config.
@@ -63,6 +107,18 @@ func WithAWSConfig(config aws.Config) Option {
 	}
 }
 
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a ParameterStore with specific options.
 	Option  func(options *options)
@@ -5,6 +5,7 @@
 //
 // It requires following permissions to access object from AWS S3:
 //   - ssm:GetParametersByPath
+//   - kms:Decrypt (only for SecureString parameters encrypted with customer managed key)
 //
 // # Change notification
 //
@@ -36,13 +37,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 
 	imaps "github.com/nil-go/konf/provider/parameterstore/internal/maps"
+	"github.com/nil-go/konf/provider/parameterstore/internal/watch"
 )
 
 type ParameterStore struct {
 	pollInterval time.Duration
+	debounce     time.Duration
+	trimPrefix   string
 	splitter     func(string) []string
 
-	onStatus  func(bool, error)
+	ctx       context.Context //nolint:containedctx
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -70,7 +75,13 @@ func (p *ParameterStore) Load() (map[string]any, error) {
 		return nil, errNil
 	}
 
-	values, _, err := p.load(context.Background())
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	values, _, err := p.load(ctx)
+	p.reporter.Load(ctx, p, err, start)
 
 	return values, err
 }
@@ -95,10 +106,12 @@ func (p *ParameterStore) Watch(ctx context.Context, onChange func(map[string]any
 		case <-ticker.C:
 			p.changed()
 		case <-p.changedCh:
-			values, changed, err := p.load(ctx)
-			if p.onStatus != nil {
-				p.onStatus(changed, err)
+			if !watch.Debounce(ctx, p.debounce, p.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := p.load(ctx)
+			p.reporter.Watch(ctx, p, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -136,6 +149,11 @@ func (p *ParameterStore) load(ctx context.Context) (map[string]any, bool, error)
 
 	values := make(map[string]any)
 	for key, value := range resp {
+		if name, ok := strings.CutPrefix(key, p.trimPrefix); ok &&
+			(name == "" || name[0] == '/' || strings.HasSuffix(p.trimPrefix, "/")) {
+			// Only trim the prefix at the boundary of hierarchy.
+			key = name
+		}
 		keys := splitter(key)
 		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
 			continue
@@ -174,17 +192,28 @@ func (p *ParameterStore) OnEvent(msg []byte) error {
 }
 
 func (p *ParameterStore) Status(onStatus func(bool, error)) {
-	p.onStatus = onStatus
+	p.reporter.OnStatus = onStatus
 }
 
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (p *ParameterStore) StatusDetailed(onStatus func(StatusInfo)) {
+	p.reporter.OnStatusDetailed = onStatus
+}
+
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (p *ParameterStore) String() string {
 	return "parameter-store:" + p.client.path
 }
 
 type clientProxy struct {
-	path    string
-	filters []types.ParameterStringFilter
-	config  aws.Config
+	path              string
+	filters           []types.ParameterStringFilter
+	withoutDecryption bool
+	config            aws.Config
 
 	client       *ssm.Client
 	lastVersions atomic.Pointer[map[string]int64]
@@ -213,7 +242,7 @@ func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error)
 			Path:             aws.String(p.path),
 			ParameterFilters: p.filters,
 			Recursive:        aws.Bool(true),
-			WithDecryption:   aws.Bool(true),
+			WithDecryption:   aws.Bool(!p.withoutDecryption),
 			NextToken:        nextToken,
 		})
 		if err != nil {
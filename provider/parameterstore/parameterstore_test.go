@@ -58,17 +58,105 @@ func TestParameterStore_Load(t *testing.T) {
 			loader := parameterstore.New(
 				append(testcase.opts, parameterstore.WithAWSConfig(cfg))...,
 			)
+			var status parameterstore.StatusInfo
+			loader.StatusDetailed(func(info parameterstore.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 			}
 		})
 	}
 }
 
+func TestParameterStore_Load_decryption(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []parameterstore.Option
+		expected    bool
+	}{
+		{
+			description: "default",
+			expected:    true,
+		},
+		{
+			description: "with decryption",
+			opts:        []parameterstore.Option{parameterstore.WithDecryption(true)},
+			expected:    true,
+		},
+		{
+			description: "without decryption",
+			opts:        []parameterstore.Option{parameterstore.WithDecryption(false)},
+			expected:    false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var decryption atomic.Bool
+			cfg, err := config.LoadDefaultConfig(
+				context.Background(),
+				config.WithAPIOptions([]func(*middleware.Stack) error{
+					func(stack *middleware.Stack) error {
+						return stack.Initialize.Add(
+							middleware.InitializeMiddlewareFunc(
+								"mock",
+								func(
+									_ context.Context,
+									input middleware.InitializeInput,
+									_ middleware.InitializeHandler,
+								) (middleware.InitializeOutput, middleware.Metadata, error) {
+									decryption.Store(aws.ToBool(input.Parameters.(*ssm.GetParametersByPathInput).WithDecryption))
+
+									return middleware.InitializeOutput{
+										Result: &ssm.GetParametersByPathOutput{},
+									}, middleware.Metadata{}, nil
+								},
+							),
+							middleware.Before,
+						)
+					},
+				}),
+			)
+			assert.NoError(t, err)
+
+			loader := parameterstore.New(append(testcase.opts, parameterstore.WithAWSConfig(cfg))...)
+			_, err = loader.Load()
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, decryption.Load())
+		})
+	}
+}
+
+func TestParameterStore_Load_context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	loader := parameterstore.New(
+		parameterstore.WithAWSConfig(aws.Config{
+			Region: "us-west-2",
+			Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				<-ctx.Done() // Simulate a slow credential provider.
+
+				return aws.Credentials{}, ctx.Err()
+			}),
+		}),
+		parameterstore.WithContext(ctx),
+	)
+	_, err := loader.Load()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
 func TestParameterStore_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -227,6 +315,51 @@ func testcases() []testcase {
 				"k": "v",
 			},
 		},
+		{
+			description: "with trim prefix",
+			opts: []parameterstore.Option{
+				parameterstore.WithPath("/prod"),
+				parameterstore.WithTrimPrefix("/prod"),
+				parameterstore.WithPollInterval(10 * time.Millisecond),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetParametersByPath":
+					return middleware.FinalizeOutput{
+						Result: &ssm.GetParametersByPathOutput{
+							Parameters: []types.Parameter{
+								{
+									Name:    aws.String("/prod/app/db/host"),
+									Value:   aws.String("localhost"),
+									Version: 1,
+								},
+								{
+									Name:    aws.String("/production/k"),
+									Value:   aws.String("v"),
+									Version: 1,
+								},
+							},
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			expected: map[string]any{
+				"app": map[string]any{
+					"db": map[string]any{
+						"host": "localhost",
+					},
+				},
+				"production": map[string]any{
+					"k": "v",
+				},
+			},
+		},
 		{
 			description: "with nil splitter",
 			opts: []parameterstore.Option{
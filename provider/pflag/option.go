@@ -4,6 +4,8 @@
 package pflag
 
 import (
+	"log/slog"
+
 	"github.com/spf13/pflag"
 )
 
@@ -18,6 +20,26 @@ func WithPrefix(prefix string) Option {
 	}
 }
 
+// WithTrimPrefix trims the prefix provided by WithPrefix from the flag names
+// before splitting them into nested keys.
+//
+// For example, with prefix "app.", the flag "app.db.host" is loaded as "db.host".
+func WithTrimPrefix() Option {
+	return func(options *options) {
+		options.trimPrefix = true
+	}
+}
+
+// WithNameNormalizeFunc provides the function used to canonicalize flag names
+// before matching the prefix and splitting them into nested keys.
+//
+// For example, with strings.ToLower, the flag "Server.Port" is loaded as "server.port".
+func WithNameNormalizeFunc(normalize func(string) string) Option {
+	return func(options *options) {
+		options.normalize = normalize
+	}
+}
+
 // WithFlagSet provides the [pflag.FlagSet] that loads configuration from.
 //
 // The default flag set is [pflag.CommandLine] plus [flag.CommandLine].
@@ -38,6 +60,18 @@ func WithNameSplitter(splitter func(string) []string) Option {
 	}
 }
 
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a PFlag with specific options.
 	Option  func(*options)
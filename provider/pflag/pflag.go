@@ -6,30 +6,38 @@
 // PFlag loads flags in [pflag.CommandLine] whose names starts with the given prefix
 // and returns them as a nested map[string]any.
 // The unchanged flags with zero default value are skipped to avoid
-// overriding values set by other loader.
+// overriding values set by other loader. The flags registered with shorthand only
+// are loaded with the shorthand as the name.
 //
 // It splits the names by delimiter. For example, with the default delimiter ".",
 // the flag `parent.child.key="1"` is loaded as `{parent: {child: {key: "1"}}}`.
 package pflag
 
 import (
+	"context"
 	"flag"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	"github.com/nil-go/konf/provider/pflag/internal/maps"
+	"github.com/nil-go/konf/provider/pflag/internal/watch"
 )
 
 // PFlag is a Provider that loads configuration from flags defined by [spf13/pflag].
 //
 // To create a new PFlag, call [New].
 type PFlag struct {
-	konf     konf
-	prefix   string
-	set      *pflag.FlagSet
-	splitter func(string) []string
+	konf       konf
+	prefix     string
+	trimPrefix bool
+	set        *pflag.FlagSet
+	normalize  func(string) string
+	splitter   func(string) []string
+
+	reporter watch.Reporter
 }
 
 // New creates a PFlag with the given Option(s).
@@ -48,7 +56,15 @@ func New(konf konf, opts ...Option) PFlag {
 	return PFlag(*option)
 }
 
-func (f PFlag) Load() (map[string]any, error) { //nolint:cyclop
+func (f PFlag) Load() (map[string]any, error) {
+	start := time.Now()
+	values := f.load()
+	f.reporter.Load(context.Background(), f, nil, start)
+
+	return values, nil
+}
+
+func (f PFlag) load() map[string]any { //nolint:cyclop,funlen
 	set := f.set
 	if set == nil {
 		if !pflag.Parsed() {
@@ -77,11 +93,22 @@ func (f PFlag) Load() (map[string]any, error) { //nolint:cyclop
 	values := make(map[string]any)
 	set.VisitAll(
 		func(flag *pflag.Flag) {
-			if f.prefix != "" && !strings.HasPrefix(flag.Name, f.prefix) {
+			name := flag.Name
+			if name == "" {
+				// The flag is registered with shorthand only.
+				name = flag.Shorthand
+			}
+			if f.normalize != nil {
+				name = f.normalize(name)
+			}
+			if f.prefix != "" && !strings.HasPrefix(name, f.prefix) {
 				return
 			}
+			if f.trimPrefix {
+				name = strings.TrimPrefix(name, f.prefix)
+			}
 
-			keys := splitter(flag.Name)
+			keys := splitter(name)
 			if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
 				return
 			}
@@ -97,7 +124,7 @@ func (f PFlag) Load() (map[string]any, error) { //nolint:cyclop
 		},
 	)
 
-	return values, nil
+	return values
 }
 
 func zeroDefaultValue(flag *pflag.Flag) bool { //nolint:cyclop
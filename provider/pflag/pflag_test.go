@@ -4,7 +4,9 @@
 package pflag_test
 
 import (
+	"bytes"
 	"flag"
+	"log/slog"
 	"strings"
 	"testing"
 
@@ -103,6 +105,55 @@ func TestPFlag_Load(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "with trim prefix",
+			konf:        &konfStub{exists: false},
+			opts: []kflag.Option{
+				kflag.WithFlagSet(appSet),
+				kflag.WithPrefix("app."),
+				kflag.WithTrimPrefix(),
+			},
+			expected: map[string]any{
+				"db": map[string]any{
+					"host": "localhost",
+				},
+			},
+		},
+		{
+			description: "with trim prefix and exists",
+			konf:        &konfStub{exists: true},
+			opts: []kflag.Option{
+				kflag.WithFlagSet(appSet),
+				kflag.WithPrefix("app."),
+				kflag.WithTrimPrefix(),
+			},
+			expected: map[string]any{},
+		},
+		{
+			description: "with name normalize func",
+			konf:        &konfStub{exists: false},
+			opts: []kflag.Option{
+				kflag.WithFlagSet(appSet),
+				kflag.WithNameNormalizeFunc(strings.ToLower),
+				kflag.WithPrefix("server."),
+			},
+			expected: map[string]any{
+				"server": map[string]any{
+					"port": 8080,
+				},
+			},
+		},
+		{
+			description: "with shorthand only",
+			konf:        &konfStub{exists: false},
+			opts: []kflag.Option{
+				kflag.WithFlagSet(appSet),
+				kflag.WithPrefix("v"),
+			},
+			expected: map[string]any{
+				"v": true,
+			},
+		},
 	}
 
 	pflag.CommandLine.SortFlags = false
@@ -118,6 +169,18 @@ func TestPFlag_Load(t *testing.T) {
 	}
 }
 
+func TestPFlag_Load_log(t *testing.T) {
+	t.Parallel()
+
+	set := pflag.NewFlagSet("set", pflag.ContinueOnError)
+	set.String("p.k", "v", "")
+	buf := &bytes.Buffer{}
+	values, err := kflag.New(nil, kflag.WithFlagSet(set), kflag.WithLogHandler(logHandler(buf))).Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+	assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+}
+
 func TestPFlag_String(t *testing.T) {
 	t.Parallel()
 
@@ -154,7 +217,10 @@ func TestPFlag_String(t *testing.T) {
 	}
 }
 
-var set = &pflag.FlagSet{}
+var (
+	set    = &pflag.FlagSet{}
+	appSet = &pflag.FlagSet{}
+)
 
 func init() {
 	pflag.String("p.k", "", "")
@@ -165,6 +231,11 @@ func init() {
 	pflag.Parse()
 
 	set.String("k", "v", "")
+
+	appSet.String("app.db.host", "localhost", "")
+	appSet.Int("Server.Port", 8080, "")
+	appSet.BoolP("", "v", false, "")
+	_ = appSet.Parse([]string{"-v"})
 }
 
 type konfStub struct {
@@ -174,3 +245,16 @@ type konfStub struct {
 func (k konfStub) Exists([]string) bool {
 	return k.exists
 }
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
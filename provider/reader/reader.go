@@ -0,0 +1,79 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package reader loads configuration from bytes.
+//
+// Reader returns a nested map[string]any that is parsed from the given bytes
+// with the given unmarshal function. It's useful for tests and the default configuration
+// embedded in the binary via [embed], which is usually loaded before other loaders
+// so it could be overridden, e.g. by environment variables.
+//
+// The unmarshal function must be able to unmarshal the bytes into a map[string]any.
+// For example, with the default json.Unmarshal, the bytes are parsed as JSON.
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nil-go/konf/internal/watch"
+)
+
+// Reader is a Provider that loads configuration from bytes.
+//
+// To create a new Reader, call [New].
+type Reader struct {
+	data      []byte
+	unmarshal func([]byte, any) error
+
+	reporter watch.Reporter
+}
+
+// New creates a Reader with the given bytes, unmarshal function and Option(s).
+// If the unmarshal function is nil, it uses json.Unmarshal.
+func New(data []byte, unmarshal func([]byte, any) error, opts ...Option) Reader {
+	option := &options{
+		data:      data,
+		unmarshal: unmarshal,
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+	if option.unmarshal == nil {
+		option.unmarshal = json.Unmarshal
+	}
+
+	return Reader(*option)
+}
+
+func (r Reader) Load() (map[string]any, error) {
+	start := time.Now()
+	values, err := r.load()
+	r.reporter.Load(context.Background(), r, err, start)
+
+	return values, err
+}
+
+func (r Reader) load() (map[string]any, error) {
+	unmarshal := r.unmarshal
+	if unmarshal == nil { // To support zero Reader
+		unmarshal = json.Unmarshal
+	}
+
+	var out map[string]any
+	if len(r.data) == 0 {
+		return out, nil
+	}
+	if err := unmarshal(r.data, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return out, nil
+}
+
+func (r Reader) String() string {
+	return "bytes:" + strconv.Itoa(len(r.data))
+}
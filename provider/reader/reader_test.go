@@ -0,0 +1,91 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package reader_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/nil-go/konf/internal/assert"
+	"github.com/nil-go/konf/provider/reader"
+)
+
+func TestReader_empty(t *testing.T) {
+	var loader reader.Reader
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+}
+
+func TestReader_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		data        []byte
+		unmarshal   func([]byte, any) error
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "empty",
+		},
+		{
+			description: "json",
+			data:        []byte(`{"p":{"k":"v"}}`),
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v",
+				},
+			},
+		},
+		{
+			description: "unmarshal error",
+			data:        []byte(`{"p":{"k":"v"}}`),
+			unmarshal: func([]byte, any) error {
+				return errors.New("unmarshal error")
+			},
+			err: "unmarshal: unmarshal error",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			values, err := reader.New(testcase.data, testcase.unmarshal, reader.WithLogHandler(logHandler(buf))).Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.err)+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestReader_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "bytes:15", reader.New([]byte(`{"p":{"k":"v"}}`), nil).String())
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
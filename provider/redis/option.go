@@ -0,0 +1,59 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package redis
+
+import (
+	"log/slog"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WithClient provides the Redis client.
+// It takes precedence over the address passed to New.
+//
+// By default, it connects to the Redis server with the address passed to New.
+func WithClient(client *redis.Client) Option {
+	return func(options *options) {
+		options.client.client = client
+	}
+}
+
+// WithNameSplitter provides the function used to split field names of the hash into nested keys.
+// If it returns an nil/[]string{}/[]string{""}, the field will be ignored.
+//
+// For example, with the default splitter, a field name like "parent.child.key"
+// would be split into "parent", "child", and "key".
+func WithNameSplitter(splitter func(string) []string) Option {
+	return func(options *options) {
+		options.splitter = splitter
+	}
+}
+
+// WithUnmarshal provides the function used to parses the configuration if the key is a string.
+// The unmarshal function must be able to unmarshal the configuration into a map[string]any.
+//
+// The default function is json.Unmarshal.
+func WithUnmarshal(unmarshal func([]byte, any) error) Option {
+	return func(options *options) {
+		options.unmarshal = unmarshal
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
+type (
+	// Option configures the a Redis with specific options.
+	Option  func(options *options)
+	options Redis
+)
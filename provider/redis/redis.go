@@ -0,0 +1,252 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package redis loads configuration from [Redis].
+//
+// Redis loads the configuration from the given key, which is either a hash or a string.
+// For a hash, it splits the field names by "." (or the splitter of WithNameSplitter),
+// e.g. the field `parent.child.key` is loaded as `{parent: {child: {key: "value"}}}`.
+// For a string, it unmarshals the value with the unmarshal function (default json.Unmarshal).
+//
+// # Change notification
+//
+// It subscribes the [keyspace notifications] of the key,
+// and pushes the latest configuration once the key is changed.
+// The keyspace notifications must be enabled on the Redis server,
+// e.g. `CONFIG SET notify-keyspace-events Kgh$x` for hash and string keys.
+//
+// [Redis]: https://redis.io
+// [keyspace notifications]: https://redis.io/docs/latest/develop/use/keyspace-notifications/
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/nil-go/konf/provider/redis/internal/maps"
+	"github.com/nil-go/konf/provider/redis/internal/watch"
+)
+
+// Redis is a Provider that loads configuration from Redis.
+//
+// To create a new Redis, call [New].
+type Redis struct {
+	splitter  func(string) []string
+	unmarshal func([]byte, any) error
+
+	reporter watch.Reporter
+	client   clientProxy
+}
+
+// New creates a Redis with the given address of Redis server, the key and Option(s).
+func New(addr, key string, opts ...Option) *Redis {
+	option := &options{
+		client: clientProxy{
+			addr: addr,
+			key:  key,
+		},
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+
+	return (*Redis)(option)
+}
+
+var errNil = errors.New("nil Redis")
+
+func (r *Redis) Load() (map[string]any, error) {
+	if r == nil {
+		return nil, errNil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second) //nolint:mnd
+	defer cancel()
+
+	start := time.Now()
+	values, _, err := r.load(ctx)
+	r.reporter.Load(ctx, r, err, start)
+
+	return values, err
+}
+
+func (r *Redis) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if r == nil {
+		return errNil
+	}
+
+	subscription, err := r.client.subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = subscription.Close()
+		r.client.close()
+	}()
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			start := time.Now()
+			values, changed, err := r.load(ctx)
+			r.reporter.Watch(ctx, r, changed, err, start)
+			if changed {
+				onChange(values)
+			}
+		}
+	}
+}
+
+func (r *Redis) load(ctx context.Context) (map[string]any, bool, error) {
+	values, err := r.values(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	// The notification is also sent for the command which does not change the value, e.g. HSET with the same value.
+	last := r.client.values.Swap(&values)
+
+	return values, last == nil || !reflect.DeepEqual(*last, values), nil
+}
+
+func (r *Redis) values(ctx context.Context) (map[string]any, error) {
+	fields, value, err := r.client.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+	if fields != nil {
+		splitter := r.splitter
+		if splitter == nil {
+			splitter = func(s string) []string { return strings.Split(s, ".") }
+		}
+		for field, value := range fields {
+			keys := splitter(field)
+			if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
+				continue
+			}
+			maps.Insert(values, keys, value)
+		}
+
+		return values, nil
+	}
+	if value == nil {
+		return values, nil
+	}
+
+	unmarshal := r.unmarshal
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	if err := unmarshal(value, &values); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return values, nil
+}
+
+func (r *Redis) Status(onStatus func(bool, error)) {
+	r.reporter.OnStatus = onStatus
+}
+
+func (r *Redis) String() string {
+	return "redis://" + r.client.address() + "/" + r.client.key
+}
+
+type clientProxy struct {
+	addr string
+	key  string
+
+	client    *redis.Client
+	ownClient bool // The client is created by the provider rather than WithClient.
+	values    atomic.Pointer[map[string]any]
+}
+
+// load returns the fields if the key is a hash, or the value if the key is a string.
+// Both are nil if the key does not exist.
+func (p *clientProxy) load(ctx context.Context) (map[string]string, []byte, error) {
+	client := p.redis()
+	typ, err := client.Type(ctx, p.key).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("get type of redis key: %w", err)
+	}
+
+	switch typ {
+	case "hash":
+		fields, err := client.HGetAll(ctx, p.key).Result()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get redis hash: %w", err)
+		}
+
+		return fields, nil, nil
+	case "string":
+		value, err := client.Get(ctx, p.key).Bytes()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, nil, fmt.Errorf("get redis string: %w", err)
+		}
+
+		return nil, value, nil
+	case "none":
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported type of redis key %s: %s", p.key, typ)
+	}
+}
+
+func (p *clientProxy) subscribe(ctx context.Context) (*redis.PubSub, error) {
+	client := p.redis()
+	channel := fmt.Sprintf("__keyspace@%d__:%s", client.Options().DB, p.key)
+	subscription := client.Subscribe(ctx, channel)
+	// Wait for the confirmation of the subscription.
+	if _, err := subscription.Receive(ctx); err != nil {
+		_ = subscription.Close()
+
+		return nil, fmt.Errorf("subscribe redis keyspace notification: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (p *clientProxy) redis() *redis.Client {
+	if p.client == nil {
+		p.client = redis.NewClient(&redis.Options{Addr: p.addr})
+		p.ownClient = true
+	}
+
+	return p.client
+}
+
+// close closes the client if it's created by the provider.
+func (p *clientProxy) close() {
+	if !p.ownClient {
+		return
+	}
+
+	// Ignore error: it could do nothing on this error.
+	_ = p.client.Close()
+	p.client, p.ownClient = nil, false
+}
+
+func (p *clientProxy) address() string {
+	if p.client != nil {
+		return p.client.Options().Addr
+	}
+
+	return p.addr
+}
@@ -0,0 +1,334 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package redis_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+
+	kredis "github.com/nil-go/konf/provider/redis"
+	"github.com/nil-go/konf/provider/redis/internal/assert"
+)
+
+func TestRedis_empty(t *testing.T) {
+	var loader *kredis.Redis
+	values, err := loader.Load()
+	assert.EqualError(t, err, "nil Redis")
+	assert.Equal(t, nil, values)
+	err = loader.Watch(context.Background(), nil)
+	assert.EqualError(t, err, "nil Redis")
+}
+
+func TestRedis_Load(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []kredis.Option
+		hash        map[string]string
+		value       string
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "hash",
+			hash:        map[string]string{"p.k": "v", "p.d": "d"},
+			expected:    map[string]any{"p": map[string]any{"k": "v", "d": "d"}},
+		},
+		{
+			description: "with name splitter",
+			opts: []kredis.Option{
+				kredis.WithNameSplitter(func(s string) []string {
+					if s == "p.d" {
+						return nil
+					}
+
+					return strings.Split(s, "_")
+				}),
+			},
+			hash:     map[string]string{"p_k": "v", "p.d": "d"},
+			expected: map[string]any{"p": map[string]any{"k": "v"}},
+		},
+		{
+			description: "string",
+			value:       `{"p":{"k":"v"}}`,
+			expected:    map[string]any{"p": map[string]any{"k": "v"}},
+		},
+		{
+			description: "with unmarshal",
+			opts: []kredis.Option{
+				kredis.WithUnmarshal(func([]byte, any) error {
+					return errors.New("unmarshal error")
+				}),
+			},
+			value: `{"p":{"k":"v"}}`,
+			err:   "unmarshal: unmarshal error",
+		},
+		{
+			description: "key not found",
+			expected:    map[string]any{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			server := startServer(t)
+			server.set(testcase.hash, testcase.value)
+
+			buf := &bytes.Buffer{}
+			loader := kredis.New(server.addr(), "konf", append(testcase.opts, kredis.WithLogHandler(logHandler(buf)))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+				assert.Equal(t, `level=WARN msg="Error when loading configuration." error=`+strconv.Quote(testcase.err)+"\n", buf.String())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, `level=DEBUG msg="Configuration has been loaded."`+"\n", buf.String())
+			}
+		})
+	}
+}
+
+func TestRedis_Load_error(t *testing.T) {
+	t.Parallel()
+
+	server := startServer(t)
+	addr := server.addr()
+	server.close()
+
+	loader := kredis.New(addr, "konf")
+	_, err := loader.Load()
+	assert.True(t, strings.HasPrefix(err.Error(), "get type of redis key: dial tcp "))
+}
+
+func TestRedis_Watch(t *testing.T) {
+	t.Parallel()
+
+	server := startServer(t)
+	server.set(map[string]string{"p.k": "v"}, "")
+
+	client := redis.NewClient(&redis.Options{Addr: server.addr()})
+	defer func() {
+		_ = client.Close()
+	}()
+	loader := kredis.New("", "konf", kredis.WithClient(client))
+	statuses := make(chan bool, 3) //nolint:mnd
+	loader.Status(func(changed bool, err error) {
+		assert.NoError(t, err)
+		statuses <- changed
+	})
+	values, lerr := loader.Load()
+	assert.NoError(t, lerr)
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "v"}}, values)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan map[string]any)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, loader.Watch(ctx, func(values map[string]any) {
+			changes <- values
+		}))
+	}()
+	server.waitSubscribed()
+
+	server.set(map[string]string{"p.k": "c"}, "")
+	server.notify("__keyspace@0__:konf", "hset")
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "c"}}, <-changes)
+	assert.Equal(t, true, <-statuses)
+	// The notification without change does not trigger onChange.
+	server.notify("__keyspace@0__:konf", "hset")
+	assert.Equal(t, false, <-statuses)
+	server.set(map[string]string{"p.k": "d"}, "")
+	server.notify("__keyspace@0__:konf", "hset")
+	assert.Equal(t, map[string]any{"p": map[string]any{"k": "d"}}, <-changes)
+	assert.Equal(t, true, <-statuses)
+	cancel()
+	<-stopped
+	assert.NoError(t, client.Ping(context.Background()).Err()) // The client of WithClient is not closed.
+}
+
+func TestRedis_String(t *testing.T) {
+	t.Parallel()
+
+	loader := kredis.New("localhost:6379", "konf")
+	assert.Equal(t, "redis://localhost:6379/konf", loader.String())
+}
+
+// server is a fake Redis server which only supports the commands used by the provider.
+type server struct {
+	listener net.Listener
+
+	hash       map[string]string
+	value      string
+	subscriber net.Conn
+	subscribed chan struct{}
+	mutex      sync.Mutex
+}
+
+func startServer(t *testing.T) *server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := &server{listener: listener, subscribed: make(chan struct{})}
+	t.Cleanup(srv.close)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serve(conn)
+		}
+	}()
+
+	return srv
+}
+
+func (s *server) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *server) close() {
+	_ = s.listener.Close()
+}
+
+func (s *server) set(hash map[string]string, value string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.hash, s.value = hash, value
+}
+
+func (s *server) waitSubscribed() {
+	<-s.subscribed
+}
+
+func (s *server) notify(channel, event string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, _ = io.WriteString(s.subscriber, array("message", channel, event))
+}
+
+func (s *server) serve(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+
+		s.mutex.Lock()
+		var reply string
+		switch strings.ToUpper(args[0]) {
+		case "TYPE":
+			switch {
+			case s.hash != nil:
+				reply = "+hash\r\n"
+			case s.value != "":
+				reply = "+string\r\n"
+			default:
+				reply = "+none\r\n"
+			}
+		case "HGETALL":
+			fields := make([]string, 0, len(s.hash)*2) //nolint:mnd
+			for field, value := range s.hash {
+				fields = append(fields, field, value)
+			}
+			reply = array(fields...)
+		case "GET":
+			reply = bulk(s.value)
+		case "SUBSCRIBE":
+			s.subscriber = conn
+			reply = "*3\r\n" + bulk("subscribe") + bulk(args[1]) + ":1\r\n"
+		case "PING":
+			reply = "+PONG\r\n"
+		case "HELLO":
+			reply = "-ERR unknown command\r\n"
+		default:
+			reply = "+OK\r\n"
+		}
+		_, _ = io.WriteString(conn, reply)
+		if strings.EqualFold(args[0], "SUBSCRIBE") {
+			close(s.subscribed)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	args := make([]string, 0, count)
+	for range count {
+		if _, err := reader.ReadString('\n'); err != nil { // The length of the bulk string.
+			return nil, err //nolint:wrapcheck
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		args = append(args, strings.TrimSuffix(arg, "\r\n"))
+	}
+
+	return args, nil
+}
+
+func array(values ...string) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("*%d\r\n", len(values)))
+	for _, value := range values {
+		builder.WriteString(bulk(value))
+	}
+
+	return builder.String()
+}
+
+func bulk(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func logHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" || attr.Key == "loader" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// decompress decompresses the data if the content encoding is gzip,
+// or it starts with the gzip magic bytes.
+func decompress(data []byte, contentEncoding string) ([]byte, error) {
+	if contentEncoding != "gzip" && !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = reader.Close()
+	}()
+
+	if data, err = io.ReadAll(reader); err != nil {
+		return nil, fmt.Errorf("decompress: %w", err)
+	}
+
+	return data, nil
+}
@@ -4,11 +4,23 @@
 package s3
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 )
 
+// WithContext provides the context used by Load, which bounds the client setup
+// (e.g. discovering AWS credentials) and loading the configuration.
+//
+// By default, it uses context.Background() which has no deadline.
+func WithContext(ctx context.Context) Option {
+	return func(options *options) {
+		options.ctx = ctx
+	}
+}
+
 // WithAWSConfig provides the AWS Config for the AWS SDK.
 //
 // By default, it loads the default AWS Config.
@@ -27,6 +39,29 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return func(options *options) {
+		options.debounce = window
+	}
+}
+
+// WithFailFast loads the configuration immediately when Watch starts,
+// and Watch returns the error if the load fails rather than reporting it via Status.
+// It only affects Watch, since Config.Load has returned the error of the initial load already.
+// It helps when the loader is watched without being loaded first,
+// or the object becomes unavailable between Config.Load and Config.Watch.
+//
+// By default, the first load in Watch happens after the poll interval.
+func WithFailFast() Option {
+	return func(options *options) {
+		options.failFast = true
+	}
+}
+
 // WithUnmarshal provides the function used to parses the configuration.
 // The unmarshal function must be able to unmarshal the configuration into a map[string]any.
 //
@@ -37,6 +72,63 @@ func WithUnmarshal(unmarshal func([]byte, any) error) Option {
 	}
 }
 
+// WithDecompression decompresses the gzip-compressed object before unmarshalling,
+// which is detected by Content-Encoding: gzip or the gzip magic bytes.
+//
+// By default, the object is unmarshalled as is.
+func WithDecompression() Option {
+	return func(options *options) {
+		options.client.decompression = true
+	}
+}
+
+// WithVerify provides the function used to verify the downloaded configuration before unmarshalling,
+// e.g. checking the SHA-256 digest or the detached signature.
+// If it returns an error, the configuration is not applied and the error is reported via Status.
+// The data has been decompressed if WithDecompression is provided.
+//
+// By default, it does not verify the configuration.
+func WithVerify(verify func(data []byte) error) Option {
+	return func(options *options) {
+		options.verify = verify
+	}
+}
+
+// WithErrorOnEmpty returns an error if the object is empty or only contains whitespaces,
+// which guards against applying an empty configuration by the accidental truncation.
+// The error is reported via Status and the configuration is not applied.
+//
+// By default, the empty object is unmarshalled as is.
+func WithErrorOnEmpty() Option {
+	return func(options *options) {
+		options.errorOnEmpty = true
+	}
+}
+
+// WithRetry retries getting the object with exponential backoff starting from the given base delay,
+// until it succeeds or the max attempts is reached. It only retries on throttling or server errors,
+// and stops once the context deadline is exceeded.
+//
+// By default, it does not retry.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(options *options) {
+		options.client.maxAttempts = maxAttempts
+		options.client.baseDelay = baseDelay
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(options *options) {
+		if handler != nil {
+			options.reporter.Logger = slog.New(handler)
+		}
+	}
+}
+
 type (
 	// Option configures the a S3 with specific options.
 	Option  func(options *options)
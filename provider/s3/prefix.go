@@ -0,0 +1,146 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	imaps "github.com/nil-go/konf/provider/s3/internal/maps"
+)
+
+// NewPrefix creates an S3 with the given bucket, prefix and Option(s),
+// which loads all objects whose keys start with the prefix
+// and merges them in the lexical order of keys. The later object takes precedence.
+func NewPrefix(bucket, prefix string, opts ...Option) *S3 {
+	option := &options{
+		client: clientProxy{
+			bucket: bucket,
+			key:    prefix,
+			prefix: true,
+		},
+		changedCh: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(option)
+	}
+	option.client.timeout = option.pollInterval / 2 //nolint:mnd
+
+	return (*S3)(option)
+}
+
+func (a *S3) loadPrefix(ctx context.Context) (map[string]any, bool, error) {
+	objects, eTags, changed, err := a.client.loadPrefix(ctx)
+	if !changed || err != nil {
+		return nil, false, err
+	}
+
+	unmarshal := a.unmarshal
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	values := make(map[string]any)
+	for _, object := range objects {
+		if a.verify != nil {
+			if e := a.verify(object); e != nil {
+				return nil, false, fmt.Errorf("verify: %w", e)
+			}
+		}
+		if a.errorOnEmpty && len(bytes.TrimSpace(object)) == 0 {
+			return nil, false, errEmpty
+		}
+
+		var value map[string]any
+		if e := unmarshal(object, &value); e != nil {
+			return nil, false, fmt.Errorf("unmarshal: %w", e)
+		}
+		imaps.Merge(values, value)
+	}
+	// Store the ETags after all objects are accepted, so the rejected objects are loaded again on next poll.
+	a.client.lastETags.Store(&eTags)
+
+	return values, true, nil
+}
+
+// loadPrefix returns the content of all objects under the prefix, sorted by keys, and their ETags.
+// It reports changed if any object has been added, removed or modified since last load.
+func (p *clientProxy) loadPrefix(ctx context.Context) ([][]byte, map[string]string, bool, error) {
+	if err := p.ensureClient(ctx); err != nil {
+		return nil, nil, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
+	defer cancel()
+
+	eTags := make(map[string]string)
+	paginator := s3.NewListObjectsV2Paginator(p.client, &s3.ListObjectsV2Input{
+		Bucket: &p.bucket,
+		Prefix: &p.key,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("list objects: %w", err)
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if strings.HasSuffix(key, "/") {
+				continue // Skip the folder object.
+			}
+			eTags[key] = aws.ToString(object.ETag)
+		}
+	}
+	if last := p.lastETags.Load(); last != nil && maps.Equal(*last, eTags) {
+		return nil, nil, false, nil
+	}
+
+	keys := make([]string, 0, len(eTags))
+	for key := range eTags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	objects := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		object, err := p.readObject(ctx, key)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, eTags, true, nil
+}
+
+func (p *clientProxy) readObject(ctx context.Context, key string) ([]byte, error) {
+	resp, err := p.getObject(ctx, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get object %s: %w", key, err)
+	}
+	defer func() {
+		// Ignore error: it could do nothing on this error.
+		_ = resp.Body.Close()
+	}()
+
+	bytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read object %s: %w", key, err)
+	}
+	if p.decompression {
+		if bytes, err = decompress(bytes, aws.ToString(resp.ContentEncoding)); err != nil {
+			return nil, fmt.Errorf("object %s: %w", key, err)
+		}
+	}
+
+	return bytes, nil
+}
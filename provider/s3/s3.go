@@ -5,6 +5,7 @@
 //
 // It requires following permissions to access object from AWS S3:
 //   - s3:GetObject
+//   - s3:ListBucket (only for NewPrefix)
 //
 // # Change notification
 //
@@ -21,11 +22,13 @@
 package s3
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"path"
 	"reflect"
 	"strings"
@@ -36,6 +39,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
+
+	"github.com/nil-go/konf/provider/s3/internal/watch"
 )
 
 // S3 is a Provider that loads configuration from AWS S3.
@@ -43,9 +48,14 @@ import (
 // To create a new S3, call [New].
 type S3 struct {
 	unmarshal    func([]byte, any) error
+	verify       func([]byte) error
+	errorOnEmpty bool
 	pollInterval time.Duration
+	debounce     time.Duration
+	failFast     bool
 
-	onStatus  func(bool, error)
+	ctx       context.Context //nolint:containedctx
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -71,14 +81,23 @@ func New(uri string, opts ...Option) *S3 {
 	return (*S3)(option)
 }
 
-var errNil = errors.New("nil S3")
+var (
+	errNil   = errors.New("nil S3")
+	errEmpty = errors.New("empty configuration")
+)
 
 func (a *S3) Load() (map[string]any, error) {
 	if a == nil {
 		return nil, errNil
 	}
 
-	values, _, err := a.load(context.Background())
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	values, _, err := a.load(ctx)
+	a.reporter.Load(ctx, a, err, start)
 
 	return values, err
 }
@@ -91,6 +110,17 @@ func (a *S3) Watch(ctx context.Context, onChange func(map[string]any)) error {
 		a.changedCh = make(chan struct{}, 1)
 	}
 
+	if a.failFast {
+		// Load immediately so the misconfiguration is surfaced before polling.
+		values, changed, err := a.load(ctx)
+		if err != nil {
+			return err
+		}
+		if changed {
+			onChange(values)
+		}
+	}
+
 	pollInterval := time.Minute
 	if a.pollInterval > 0 {
 		pollInterval = a.pollInterval
@@ -105,10 +135,12 @@ func (a *S3) Watch(ctx context.Context, onChange func(map[string]any)) error {
 		case <-ticker.C:
 			a.changed()
 		case <-a.changedCh:
-			values, changed, err := a.load(ctx)
-			if a.onStatus != nil {
-				a.onStatus(changed, err)
+			if !watch.Debounce(ctx, a.debounce, a.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := a.load(ctx)
+			a.reporter.Watch(ctx, a, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -117,11 +149,24 @@ func (a *S3) Watch(ctx context.Context, onChange func(map[string]any)) error {
 }
 
 func (a *S3) load(ctx context.Context) (map[string]any, bool, error) {
-	resp, changed, err := a.client.load(ctx)
+	if a.client.prefix {
+		return a.loadPrefix(ctx)
+	}
+
+	resp, eTag, changed, err := a.client.load(ctx)
 	if !changed || err != nil {
 		return nil, false, err
 	}
 
+	if a.verify != nil {
+		if e := a.verify(resp); e != nil {
+			return nil, false, fmt.Errorf("verify: %w", e)
+		}
+	}
+	if a.errorOnEmpty && len(bytes.TrimSpace(resp)) == 0 {
+		return nil, false, errEmpty
+	}
+
 	unmarshal := a.unmarshal
 	if unmarshal == nil {
 		unmarshal = json.Unmarshal
@@ -130,6 +175,8 @@ func (a *S3) load(ctx context.Context) (map[string]any, bool, error) {
 	if e := unmarshal(resp, &values); e != nil {
 		return nil, false, fmt.Errorf("unmarshal: %w", e)
 	}
+	// Store the ETag after the object is accepted, so the rejected object is loaded again on next poll.
+	a.client.eTag.Store(eTag)
 
 	return values, true, nil
 }
@@ -171,7 +218,7 @@ func (a *S3) OnEvent(msg []byte) error { //nolint:cyclop
 
 	if event.Source == "aws.s3" &&
 		event.Detail.Bucket.Name == a.client.bucket &&
-		event.Detail.Object.Key == a.client.key {
+		a.client.match(event.Detail.Object.Key) {
 		if event.DetailType == "Object Created" {
 			// Trigger to reload the configuration.
 			a.changed()
@@ -184,7 +231,7 @@ func (a *S3) OnEvent(msg []byte) error { //nolint:cyclop
 		record := event.Records[0]
 		if record.EventSource == "aws:s3" &&
 			record.S3.Bucket.Name == a.client.bucket &&
-			record.S3.Object.Key == a.client.key {
+			a.client.match(record.S3.Object.Key) {
 			if strings.HasPrefix(record.EventName, "ObjectCreated:") {
 				// Trigger to reload the configuration.
 				a.changed()
@@ -206,10 +253,24 @@ func (a *S3) changed() {
 }
 
 func (a *S3) Status(onStatus func(bool, error)) {
-	a.onStatus = onStatus
+	a.reporter.OnStatus = onStatus
 }
 
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (a *S3) StatusDetailed(onStatus func(StatusInfo)) {
+	a.reporter.OnStatusDetailed = onStatus
+}
+
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (a *S3) String() string {
+	if a.client.prefix {
+		return "s3://" + a.client.bucket + "/" + a.client.key + "*"
+	}
+
 	return "s3://" + path.Join(a.client.bucket, a.client.key)
 }
 
@@ -217,39 +278,56 @@ type clientProxy struct {
 	config aws.Config
 	bucket string
 	key    string
+	prefix bool
+
+	client        *s3.Client
+	decompression bool
 
-	client *s3.Client
+	timeout     time.Duration
+	maxAttempts int
+	baseDelay   time.Duration
+	eTag        atomic.Pointer[string]
+	lastETags   atomic.Pointer[map[string]string]
+}
+
+func (p *clientProxy) match(key string) bool {
+	if p.prefix {
+		return strings.HasPrefix(key, p.key)
+	}
 
-	timeout time.Duration
-	eTag    atomic.Pointer[string]
+	return key == p.key
 }
 
-func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
+func (p *clientProxy) ensureClient(ctx context.Context) error {
 	if p.client == nil {
 		if reflect.ValueOf(p.config).IsZero() {
 			var err error
 			if p.config, err = config.LoadDefaultConfig(ctx); err != nil {
-				return nil, false, fmt.Errorf("load default AWS config: %w", err)
+				return fmt.Errorf("load default AWS config: %w", err)
 			}
 		}
 		p.client = s3.NewFromConfig(p.config)
 	}
 
+	return nil
+}
+
+func (p *clientProxy) load(ctx context.Context) ([]byte, *string, bool, error) {
+	if err := p.ensureClient(ctx); err != nil {
+		return nil, nil, false, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, max(p.timeout, 10*time.Second)) //nolint:mnd
 	defer cancel()
 
-	resp, err := p.client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket:      &p.bucket,
-		Key:         &p.key,
-		IfNoneMatch: p.eTag.Load(),
-	})
+	resp, err := p.getObject(ctx, p.key, p.eTag.Load())
 	if err != nil {
 		var ae smithy.APIError
 		if errors.As(err, &ae) && ae.ErrorCode() == "NotModified" {
-			return nil, false, nil
+			return nil, nil, false, nil
 		}
 
-		return nil, false, fmt.Errorf("get object: %w", err)
+		return nil, nil, false, fmt.Errorf("get object: %w", err)
 	}
 	defer func() {
 		// Ignore error: it could do nothing on this error.
@@ -257,14 +335,69 @@ func (p *clientProxy) load(ctx context.Context) ([]byte, bool, error) {
 	}()
 
 	if resp.ETag == p.eTag.Load() {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
-	p.eTag.Store(resp.ETag)
 
 	bytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, false, fmt.Errorf("read object: %w", err)
+		return nil, nil, false, fmt.Errorf("read object: %w", err)
+	}
+	if p.decompression {
+		if bytes, err = decompress(bytes, aws.ToString(resp.ContentEncoding)); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	return bytes, resp.ETag, true, nil
+}
+
+// getObject gets the object with exponential backoff on throttling or server errors
+// if the retry is enabled by WithRetry.
+func (p *clientProxy) getObject(ctx context.Context, key string, eTag *string) (*s3.GetObjectOutput, error) {
+	delay := p.baseDelay
+	for attempt := 1; ; attempt++ {
+		resp, err := p.client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:      &p.bucket,
+			Key:         &key,
+			IfNoneMatch: eTag,
+		})
+		if err == nil || attempt >= p.maxAttempts || !retryable(err) {
+			return resp, err //nolint:wrapcheck
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err //nolint:wrapcheck // No time left for next attempt.
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return nil, err //nolint:wrapcheck
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func retryable(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NotModified", "NoSuchKey":
+			return false
+		case "SlowDown", "Throttling", "ThrottlingException", "RequestTimeout",
+			"InternalError", "ServiceUnavailable":
+			return true
+		}
+	}
+
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		code := respErr.HTTPStatusCode()
+
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
 	}
 
-	return bytes, true, nil
+	return false
 }
@@ -4,6 +4,8 @@
 package s3_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"io"
@@ -16,7 +18,10 @@ import (
 	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/middleware"
+	"github.com/aws/smithy-go/transport/http"
 
 	ks3 "github.com/nil-go/konf/provider/s3"
 	"github.com/nil-go/konf/provider/s3/internal/assert"
@@ -60,17 +65,260 @@ func TestS3_Load(t *testing.T) {
 				"bucket/key",
 				append(testcase.opts, ks3.WithAWSConfig(cfg))...,
 			)
+			var status ks3.StatusInfo
+			loader.StatusDetailed(func(info ks3.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 			}
 		})
 	}
 }
 
+func TestS3_Load_context(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	loader := ks3.New("bucket/key",
+		ks3.WithAWSConfig(aws.Config{
+			Region: "us-west-2",
+			Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+				<-ctx.Done() // Simulate a slow credential provider.
+
+				return aws.Credentials{}, ctx.Err()
+			}),
+		}),
+		ks3.WithContext(ctx),
+	)
+	_, err := loader.Load()
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestS3_Load_retry(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		opts        []ks3.Option
+		errs        []error
+		attempts    int32
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "no retry",
+			errs:        []error{&smithy.GenericAPIError{Code: "ServiceUnavailable"}},
+			attempts:    1,
+			err:         "get object: operation error S3: GetObject, api error ServiceUnavailable: ",
+		},
+		{
+			description: "retry until success",
+			opts:        []ks3.Option{ks3.WithRetry(3, time.Millisecond)},
+			errs: []error{
+				&smithy.GenericAPIError{Code: "SlowDown"},
+				&smithy.GenericAPIError{Code: "InternalError"},
+			},
+			attempts: 3,
+			expected: map[string]any{"k": "v"},
+		},
+		{
+			description: "retry until max attempts",
+			opts:        []ks3.Option{ks3.WithRetry(2, time.Millisecond)},
+			errs: []error{
+				&smithy.GenericAPIError{Code: "SlowDown"},
+				&smithy.GenericAPIError{Code: "SlowDown"},
+				&smithy.GenericAPIError{Code: "SlowDown"},
+			},
+			attempts: 2,
+			err:      "get object: operation error S3: GetObject, api error SlowDown: ",
+		},
+		{
+			description: "no retry on no such key",
+			opts:        []ks3.Option{ks3.WithRetry(3, time.Millisecond)},
+			errs:        []error{&smithy.GenericAPIError{Code: "NoSuchKey"}},
+			attempts:    1,
+			err:         "get object: operation error S3: GetObject, api error NoSuchKey: ",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+			cfg, err := config.LoadDefaultConfig(
+				context.Background(),
+				config.WithAPIOptions([]func(*middleware.Stack) error{
+					func(stack *middleware.Stack) error {
+						return stack.Finalize.Add(
+							middleware.FinalizeMiddlewareFunc(
+								"mock",
+								func(
+									context.Context,
+									middleware.FinalizeInput,
+									middleware.FinalizeHandler,
+								) (middleware.FinalizeOutput, middleware.Metadata, error) {
+									if attempt := int(attempts.Add(1)); attempt <= len(testcase.errs) {
+										return middleware.FinalizeOutput{}, middleware.Metadata{}, testcase.errs[attempt-1]
+									}
+
+									return middleware.FinalizeOutput{
+										Result: &s3.GetObjectOutput{
+											Body: io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+											ETag: aws.String("k42"),
+										},
+									}, middleware.Metadata{}, nil
+								},
+							),
+							middleware.Before,
+						)
+					},
+				}),
+			)
+			assert.NoError(t, err)
+
+			loader := ks3.New("bucket/key", append(testcase.opts, ks3.WithAWSConfig(cfg))...)
+			values, err := loader.Load()
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+			}
+			assert.Equal(t, testcase.attempts, attempts.Load())
+		})
+	}
+}
+
+func TestS3_Load_verify(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							_ context.Context,
+							in middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							if in.Request.(*http.Request).Header.Get("If-None-Match") == "k42" {
+								return middleware.FinalizeOutput{}, middleware.Metadata{},
+									&smithy.GenericAPIError{Code: "NotModified"}
+							}
+
+							return middleware.FinalizeOutput{
+								Result: &s3.GetObjectOutput{
+									Body: io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+									ETag: aws.String("k42"),
+								},
+							}, middleware.Metadata{}, nil
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	var verified atomic.Int32
+	loader := ks3.New("bucket/key",
+		ks3.WithAWSConfig(cfg),
+		ks3.WithVerify(func([]byte) error {
+			if verified.Add(1) == 1 {
+				return errors.New("verify error")
+			}
+
+			return nil
+		}),
+	)
+	_, err = loader.Load()
+	assert.EqualError(t, err, "verify: verify error")
+	// The object which failed verification is loaded again.
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "v"}, values)
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+}
+
+func TestS3_NewPrefix(t *testing.T) {
+	t.Parallel()
+
+	var eTag atomic.Value
+	eTag.Store("b1")
+	cfg, err := config.LoadDefaultConfig(
+		context.Background(),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"mock",
+						func(
+							ctx context.Context,
+							input middleware.FinalizeInput,
+							_ middleware.FinalizeHandler,
+						) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							switch awsMiddleware.GetOperationName(ctx) {
+							case "ListObjectsV2":
+								return middleware.FinalizeOutput{
+									Result: &s3.ListObjectsV2Output{
+										Contents: []types.Object{
+											{Key: aws.String("config/"), ETag: aws.String("d")},
+											{Key: aws.String("config/b.json"), ETag: aws.String(eTag.Load().(string))},
+											{Key: aws.String("config/a.json"), ETag: aws.String("a")},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							case "GetObject":
+								body := `{"k":"a","a":"a"}`
+								if strings.HasSuffix(input.Request.(*http.Request).URL.Path, "b.json") {
+									body = `{"k":"` + eTag.Load().(string) + `"}`
+								}
+
+								return middleware.FinalizeOutput{
+									Result: &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(body))},
+								}, middleware.Metadata{}, nil
+							default:
+								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+							}
+						},
+					),
+					middleware.Before,
+				)
+			},
+		}),
+	)
+	assert.NoError(t, err)
+
+	loader := ks3.NewPrefix("bucket", "config/", ks3.WithAWSConfig(cfg))
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "b1", "a": "a"}, values)
+
+	// Unchanged since no object has been changed.
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, nil, values)
+
+	eTag.Store("b2")
+	values, err = loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"k": "b2", "a": "a"}, values)
+}
+
 func TestS3_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -182,6 +430,110 @@ func testcases() []testcase {
 				"k": "v",
 			},
 		},
+		{
+			description: "with decompression",
+			opts: []ks3.Option{
+				ks3.WithDecompression(),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body:            io.NopCloser(strings.NewReader(compress(`{"k":"v"}`))),
+							ETag:            aws.String("k42"),
+							ContentEncoding: aws.String("gzip"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "with decompression (magic bytes)",
+			opts: []ks3.Option{
+				ks3.WithDecompression(),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body: io.NopCloser(strings.NewReader(compress(`{"k":"v"}`))),
+							ETag: aws.String("k42"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "with decompression (uncompressed)",
+			opts: []ks3.Option{
+				ks3.WithDecompression(),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body: io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+							ETag: aws.String("k42"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			expected: map[string]any{
+				"k": "v",
+			},
+		},
+		{
+			description: "corrupt compression",
+			opts: []ks3.Option{
+				ks3.WithDecompression(),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body:            io.NopCloser(strings.NewReader(`not compressed`)),
+							ETag:            aws.String("k42"),
+							ContentEncoding: aws.String("gzip"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			err: "decompress: gzip: invalid header",
+		},
 		{
 			description: "get object error",
 			opts: []ks3.Option{
@@ -201,6 +553,56 @@ func testcases() []testcase {
 			},
 			err: "get object: operation error S3: GetObject, get object error",
 		},
+		{
+			description: "verify error",
+			opts: []ks3.Option{
+				ks3.WithPollInterval(10 * time.Millisecond),
+				ks3.WithVerify(func([]byte) error { return errors.New("verify error") }),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body: io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+							ETag: aws.String("k42"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			err: "verify: verify error",
+		},
+		{
+			description: "empty error",
+			opts: []ks3.Option{
+				ks3.WithPollInterval(10 * time.Millisecond),
+				ks3.WithErrorOnEmpty(),
+			},
+			middleware: func(
+				ctx context.Context,
+				_ middleware.FinalizeInput,
+				_ middleware.FinalizeHandler,
+			) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				switch awsMiddleware.GetOperationName(ctx) {
+				case "GetObject":
+					return middleware.FinalizeOutput{
+						Result: &s3.GetObjectOutput{
+							Body: io.NopCloser(strings.NewReader(" \n")),
+							ETag: aws.String("k42"),
+						},
+					}, middleware.Metadata{}, nil
+				default:
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				}
+			},
+			err: "empty configuration",
+		},
 		{
 			description: "unmarshal error",
 			opts: []ks3.Option{
@@ -431,6 +833,92 @@ func watchcases() []testcase {
 	}
 }
 
+func compress(data string) string {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, _ = writer.Write([]byte(data))
+	_ = writer.Close()
+
+	return buf.String()
+}
+
+func TestS3_Watch_failFast(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		err         error
+		expected    map[string]any
+		error       string
+	}{
+		{
+			description: "success",
+			expected:    map[string]any{"k": "v"},
+		},
+		{
+			description: "error",
+			err:         &smithy.GenericAPIError{Code: "NoSuchBucket"},
+			error:       "get object: operation error S3: GetObject, api error NoSuchBucket: ",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := config.LoadDefaultConfig(
+				context.Background(),
+				config.WithAPIOptions([]func(*middleware.Stack) error{
+					func(stack *middleware.Stack) error {
+						return stack.Finalize.Add(
+							middleware.FinalizeMiddlewareFunc(
+								"mock",
+								func(
+									context.Context,
+									middleware.FinalizeInput,
+									middleware.FinalizeHandler,
+								) (middleware.FinalizeOutput, middleware.Metadata, error) {
+									if testcase.err != nil {
+										return middleware.FinalizeOutput{}, middleware.Metadata{}, testcase.err
+									}
+
+									return middleware.FinalizeOutput{
+										Result: &s3.GetObjectOutput{
+											Body: io.NopCloser(strings.NewReader(`{"k":"v"}`)),
+											ETag: aws.String("k42"),
+										},
+									}, middleware.Metadata{}, nil
+								},
+							),
+							middleware.Before,
+						)
+					},
+				}),
+			)
+			assert.NoError(t, err)
+
+			loader := ks3.New("bucket/key",
+				ks3.WithAWSConfig(cfg),
+				ks3.WithPollInterval(time.Hour),
+				ks3.WithFailFast(),
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var values map[string]any
+			err = loader.Watch(ctx, func(changed map[string]any) {
+				values = changed
+				cancel()
+			})
+			if testcase.error != "" {
+				assert.EqualError(t, err, testcase.error)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testcase.expected, values)
+		})
+	}
+}
+
 func TestS3_String(t *testing.T) {
 	t.Parallel()
 
@@ -439,4 +927,7 @@ func TestS3_String(t *testing.T) {
 
 	loader = ks3.New("s3://bucket/key")
 	assert.Equal(t, "s3://bucket/key", loader.String())
+
+	loader = ks3.NewPrefix("bucket", "config/")
+	assert.Equal(t, "s3://bucket/config/*", loader.String())
 }
@@ -5,6 +5,7 @@
 package secretmanager
 
 import (
+	"log/slog"
 	"time"
 
 	"google.golang.org/api/option"
@@ -33,6 +34,22 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithNamePrefix provides the prefix of secret names that will be loaded,
+// e.g. "svc-" to load only the secrets owned by the service.
+// The secrets are filtered on the client side after listing, in addition to the server side filter by WithFilter,
+// so only the matched secrets are accessed.
+// The prefix is removed from the secret name before splitting it into nested keys,
+// e.g. the secret "svc-p-k" is loaded as `{p: {k: "value"}}` with the prefix "svc-".
+//
+// By default, it loads all listed secrets.
+func WithNamePrefix(prefix string) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.secretPrefix = prefix
+		},
+	}
+}
+
 // WithNameSplitter provides the function used to split secret names into nested keys.
 // If it returns an nil/[]string{}/[]string{""}, the secret will be ignored.
 //
@@ -46,6 +63,46 @@ func WithNameSplitter(splitter func(string) []string) Option {
 	}
 }
 
+// WithVersion provides the function that returns the version (alias or number) to access for each secret,
+// which pins the secret to the explicit version for reproducible deployment.
+// The function receives the secret name, e.g. "p-k". If it returns an empty string, the latest version is accessed.
+//
+// By default, it accesses the latest version of all secrets.
+func WithVersion(version func(secretName string) string) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.version = version
+		},
+	}
+}
+
+// WithVersionHistory provides the number of versions loaded for each secret, including the latest version,
+// which is useful to validate the token minted under the previous version during rotation.
+// The versions are loaded into the sibling key with suffix `_versions`, keyed by the version number,
+// e.g. `p.k_versions.2` for the version 2 of secret `p-k`. The disabled or destroyed versions are skipped.
+//
+// It costs up to n-1 extra API calls of AccessSecretVersion for each secret while loading.
+// By default, it only loads the latest version.
+func WithVersionHistory(n int) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.versionHistory = n
+		},
+	}
+}
+
+// WithConcurrency provides the maximum number of secrets accessed simultaneously while loading,
+// which avoids exceeding the API quota when there are many secrets.
+//
+// The default concurrency is 10.
+func WithConcurrency(n int) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.client.concurrency = n
+		},
+	}
+}
+
 // WithPollInterval provides the interval for polling the configuration.
 //
 // The default interval is 1 minute.
@@ -57,6 +114,32 @@ func WithPollInterval(interval time.Duration) Option {
 	}
 }
 
+// WithDebounce provides the window for collapsing the changes arriving within it into one reload,
+// e.g. the same change is notified by multiple notifiers.
+//
+// By default, the changes are not debounced.
+func WithDebounce(window time.Duration) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			options.debounce = window
+		},
+	}
+}
+
+// WithLogHandler provides the slog.Handler for logs from loading configuration,
+// which includes the duration of each load.
+//
+// By default, it does not log.
+func WithLogHandler(handler slog.Handler) Option {
+	return &optionFunc{
+		fn: func(options *options) {
+			if handler != nil {
+				options.reporter.Logger = slog.New(handler)
+			}
+		},
+	}
+}
+
 type (
 	Option     = option.ClientOption
 	optionFunc struct {
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -37,8 +38,11 @@ import (
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	imaps "github.com/nil-go/konf/provider/secretmanager/internal/maps"
+	"github.com/nil-go/konf/provider/secretmanager/internal/watch"
 )
 
 // SecretManager is a Provider that loads configuration from GCP Secret Manager.
@@ -46,9 +50,10 @@ import (
 // To create a new SecretManager, call [New].
 type SecretManager struct {
 	pollInterval time.Duration
+	debounce     time.Duration
 	splitter     func(string) []string
 
-	onStatus  func(bool, error)
+	reporter  watch.Reporter
 	changedCh chan struct{}
 	client    clientProxy
 }
@@ -78,7 +83,10 @@ func (m *SecretManager) Load() (map[string]any, error) {
 		return nil, errNil
 	}
 
-	values, _, err := m.load(context.Background())
+	ctx := context.Background()
+	start := time.Now()
+	values, _, err := m.load(ctx)
+	m.reporter.Load(ctx, m, err, start)
 
 	return values, err
 }
@@ -109,10 +117,12 @@ func (m *SecretManager) Watch(ctx context.Context, onChange func(map[string]any)
 		case <-ticker.C:
 			m.changed()
 		case <-m.changedCh:
-			values, changed, err := m.load(ctx)
-			if m.onStatus != nil {
-				m.onStatus(changed, err)
+			if !watch.Debounce(ctx, m.debounce, m.changedCh) {
+				return nil
 			}
+			start := time.Now()
+			values, changed, err := m.load(ctx)
+			m.reporter.Watch(ctx, m, changed, err, start)
 			if changed {
 				onChange(values)
 			}
@@ -131,7 +141,7 @@ func (m *SecretManager) changed() {
 }
 
 func (m *SecretManager) load(ctx context.Context) (map[string]any, bool, error) {
-	resp, changed, err := m.client.load(ctx)
+	resp, versions, changed, err := m.client.load(ctx)
 	if !changed || err != nil {
 		return nil, false, err
 	}
@@ -145,12 +155,15 @@ func (m *SecretManager) load(ctx context.Context) (map[string]any, bool, error)
 
 	values := make(map[string]any)
 	for key, value := range resp {
-		keys := splitter(key)
+		keys := splitter(strings.TrimPrefix(key, m.client.secretPrefix))
 		if len(keys) == 0 || len(keys) == 1 && keys[0] == "" {
 			continue
 		}
 
 		imaps.Insert(values, keys, value)
+		for version, value := range versions[key] {
+			imaps.Insert(values, append(keys[:len(keys)-1:len(keys)-1], keys[len(keys)-1]+"_versions", version), value)
+		}
 	}
 
 	return values, true, nil
@@ -177,39 +190,54 @@ func (m *SecretManager) OnEvent(attributes map[string]string) error {
 }
 
 func (m *SecretManager) Status(onStatus func(bool, error)) {
-	m.onStatus = onStatus
+	m.reporter.OnStatus = onStatus
+}
+
+// StatusDetailed works like Status, but the callback receives the StatusInfo
+// which also carries the latency of the load.
+// Unlike Status, it's also called for the load from Load.
+func (m *SecretManager) StatusDetailed(onStatus func(StatusInfo)) {
+	m.reporter.OnStatusDetailed = onStatus
 }
 
+// StatusInfo is the status of a load of the configuration.
+type StatusInfo = watch.Status
+
 func (m *SecretManager) String() string {
 	return "secret-manager://" + m.client.project
 }
 
 type clientProxy struct {
-	project    string
-	namePrefix string
-	filter     string
+	project        string
+	namePrefix     string
+	secretPrefix   string
+	filter         string
+	version        func(string) string
+	versionHistory int
+	concurrency    int
 
 	client    *secretmanager.Client
 	opts      []option.ClientOption
 	lastETags atomic.Pointer[map[string]string]
 }
 
-func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error) { //nolint:cyclop,funlen
+//nolint:cyclop,funlen,gocognit
+func (p *clientProxy) load(ctx context.Context) (map[string]string, map[string]map[string]string, bool, error) {
 	if p.project == "" {
 		var err error
 		if p.project, err = metadata.ProjectIDWithContext(ctx); err != nil {
-			return nil, false, fmt.Errorf("get GCP project ID: %w", err)
+			return nil, nil, false, fmt.Errorf("get GCP project ID: %w", err)
 		}
 		projectNumer, err := metadata.NumericProjectIDWithContext(ctx)
 		if err != nil {
-			return nil, false, fmt.Errorf("get GCP numeric project ID: %w", err)
+			return nil, nil, false, fmt.Errorf("get GCP numeric project ID: %w", err)
 		}
 		p.namePrefix = "projects/" + projectNumer + "/secrets/"
 	}
 	if p.client == nil {
 		var err error
 		if p.client, err = secretmanager.NewClient(ctx, p.opts...); err != nil {
-			return nil, false, fmt.Errorf("create GCP secret manager client: %w", err)
+			return nil, nil, false, fmt.Errorf("create GCP secret manager client: %w", err)
 		}
 	}
 
@@ -226,53 +254,125 @@ func (p *clientProxy) load(ctx context.Context) (map[string]string, bool, error)
 			break
 		}
 		if err != nil {
-			return nil, false, fmt.Errorf("list secrets on %s: %w", p.project, err)
+			return nil, nil, false, fmt.Errorf("list secrets on %s: %w", p.project, err)
 		}
 
 		if p.namePrefix == "" {
 			p.namePrefix = strings.Join(strings.Split(resp.GetName(), "/")[0:3], "/") + "/"
 		}
+		if !strings.HasPrefix(strings.Split(resp.GetName(), "/")[3], p.secretPrefix) {
+			continue
+		}
 		eTags[resp.GetName()] = resp.GetEtag()
 	}
 
 	if last := p.lastETags.Load(); last != nil && maps.Equal(*last, eTags) {
-		return nil, false, nil
+		return nil, nil, false, nil
 	}
 	p.lastETags.Store(&eTags)
 
-	secretChan := make(chan *secretmanagerpb.AccessSecretVersionResponse, len(eTags))
+	type secret struct {
+		resp     *secretmanagerpb.AccessSecretVersionResponse
+		versions map[string]string
+	}
+	secretChan := make(chan secret, len(eTags))
 	ctx, cancel := context.WithCancelCause(ctx)
 	defer cancel(nil)
 
+	concurrency := p.concurrency
+	if concurrency <= 0 {
+		concurrency = 10
+	}
+	semaphore := make(chan struct{}, concurrency)
+
 	var waitGroup sync.WaitGroup
 	waitGroup.Add(len(eTags))
 	for name := range eTags {
 		go func() {
 			defer waitGroup.Done()
 
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				return
+			}
+
+			version := "latest"
+			if p.version != nil {
+				if v := p.version(strings.Split(name, "/")[3]); v != "" {
+					version = v
+				}
+			}
 			resp, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
-				Name: name + "/versions/latest",
+				Name: name + "/versions/" + version,
 			})
 			if err != nil {
 				cancel(fmt.Errorf("access secret %s: %w", strings.Split(name, "/")[3], err))
 
 				return
 			}
-			secretChan <- resp
+			versions, err := p.loadVersions(ctx, resp)
+			if err != nil {
+				cancel(fmt.Errorf("access secret %s: %w", strings.Split(name, "/")[3], err))
+
+				return
+			}
+			secretChan <- secret{resp: resp, versions: versions}
 		}()
 	}
 	waitGroup.Wait()
 	close(secretChan)
 
 	if err := context.Cause(ctx); err != nil && !errors.Is(err, ctx.Err()) {
-		return nil, false, err //nolint:wrapcheck
+		return nil, nil, false, err //nolint:wrapcheck
 	}
 
 	values := make(map[string]string, len(eTags))
-	for resp := range secretChan {
-		data := resp.GetPayload().GetData()
-		values[strings.Split(resp.GetName(), "/")[3]] = unsafe.String(unsafe.SliceData(data), len(data))
+	versions := make(map[string]map[string]string, len(eTags))
+	for secret := range secretChan {
+		data := secret.resp.GetPayload().GetData()
+		name := strings.Split(secret.resp.GetName(), "/")[3]
+		values[name] = unsafe.String(unsafe.SliceData(data), len(data))
+		if secret.versions != nil {
+			versions[name] = secret.versions
+		}
 	}
 
-	return values, true, nil
+	return values, versions, true, nil
+}
+
+// loadVersions loads the last versions of the secret in the given latest response,
+// including the latest (or pinned) version. It skips the versions which are disabled or destroyed.
+func (p *clientProxy) loadVersions(
+	ctx context.Context,
+	latest *secretmanagerpb.AccessSecretVersionResponse,
+) (map[string]string, error) {
+	if p.versionHistory <= 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	name, version, _ := strings.Cut(latest.GetName(), "/versions/")
+	number, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, fmt.Errorf("parse version %s: %w", version, err)
+	}
+
+	data := latest.GetPayload().GetData()
+	versions := map[string]string{version: string(data)}
+	for i := number - 1; i > 0 && i > number-p.versionHistory; i-- {
+		resp, err := p.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{
+			Name: name + "/versions/" + strconv.Itoa(i),
+		})
+		switch status.Code(err) {
+		case codes.OK:
+			versions[strconv.Itoa(i)] = string(resp.GetPayload().GetData())
+		case codes.NotFound, codes.FailedPrecondition:
+			// Skip the version which is disabled or destroyed.
+		default:
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return versions, nil
 }
@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -20,7 +21,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/nil-go/konf/provider/secretmanager"
@@ -88,6 +91,28 @@ func TestSecretManager_Load(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "with name prefix",
+			opts: []option.ClientOption{
+				secretmanager.WithNamePrefix("svc-"),
+			},
+			service: &secretManagerService{
+				values: map[string]string{
+					"projects/test/secrets/svc-p-k": "v",
+					"projects/test/secrets/p-d":     ".",
+				},
+				assert: func(m proto.Message) {
+					if request, ok := m.(*pb.AccessSecretVersionRequest); ok {
+						assert.Equal(t, "projects/test/secrets/svc-p-k/versions/latest", request.GetName())
+					}
+				},
+			},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v",
+				},
+			},
+		},
 		{
 			description: "with nil splitter",
 			opts: []option.ClientOption{
@@ -112,6 +137,62 @@ func TestSecretManager_Load(t *testing.T) {
 			},
 			expected: map[string]any{},
 		},
+		{
+			description: "with version history",
+			opts: []option.ClientOption{
+				secretmanager.WithVersionHistory(3),
+			},
+			service: &versionedSecretManagerService{
+				versions: map[string][]string{
+					"projects/test/secrets/p-k": {"v1", "", "v3", "v4"},
+					"projects/test/secrets/p-d": {"d1"},
+				},
+			},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k":          "v4",
+					"k_versions": map[string]any{"3": "v3", "4": "v4"},
+					"d":          "d1",
+					"d_versions": map[string]any{"1": "d1"},
+				},
+			},
+		},
+		{
+			description: "with version",
+			opts: []option.ClientOption{
+				secretmanager.WithVersion(func(name string) string {
+					if name == "p-k" {
+						return "3"
+					}
+
+					return ""
+				}),
+			},
+			service: &versionedSecretManagerService{
+				versions: map[string][]string{
+					"projects/test/secrets/p-k": {"v1", "", "v3", "v4"},
+					"projects/test/secrets/p-d": {"d1", "d2"},
+				},
+			},
+			expected: map[string]any{
+				"p": map[string]any{
+					"k": "v3",
+					"d": "d2",
+				},
+			},
+		},
+		{
+			description: "with unknown version",
+			opts: []option.ClientOption{
+				secretmanager.WithVersion(func(string) string { return "5" }),
+			},
+			service: &versionedSecretManagerService{
+				versions: map[string][]string{
+					"projects/test/secrets/p-k": {"v1"},
+				},
+			},
+			err: "access secret p-k: rpc error: code = NotFound desc = version not found",
+		},
 		{
 			description: "list secrets error",
 			service:     &faultySecretManagerService{method: "ListSecrets"},
@@ -136,13 +217,16 @@ func TestSecretManager_Load(t *testing.T) {
 				secretmanager.WithProject("test"),
 				option.WithGRPCConn(conn),
 			)...)
-			var values map[string]any
+			var status secretmanager.StatusInfo
+			loader.StatusDetailed(func(info secretmanager.StatusInfo) { status = info })
 			values, err := loader.Load()
+			assert.Equal(t, err, status.Err)
 			if testcase.err != "" {
 				assert.EqualError(t, err, testcase.err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, testcase.expected, values)
+				assert.Equal(t, true, status.Changed)
 				values, err = loader.Load()
 				assert.NoError(t, err)
 				assert.Equal(t, nil, values)
@@ -151,6 +235,31 @@ func TestSecretManager_Load(t *testing.T) {
 	}
 }
 
+func TestSecretManager_Load_concurrency(t *testing.T) {
+	t.Parallel()
+
+	service := &concurrentSecretManagerService{
+		secretManagerService: secretManagerService{values: make(map[string]string)},
+	}
+	expected := make(map[string]any)
+	for i := range 20 {
+		service.values["projects/test/secrets/k"+strconv.Itoa(i)] = "v" + strconv.Itoa(i)
+		expected["k"+strconv.Itoa(i)] = "v" + strconv.Itoa(i)
+	}
+	conn, closer := grpcServer(t, service)
+	defer closer()
+
+	loader := secretmanager.New(
+		secretmanager.WithConcurrency(2),
+		secretmanager.WithProject("test"),
+		option.WithGRPCConn(conn),
+	)
+	values, err := loader.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, values)
+	assert.Equal(t, true, service.maxRunning.Load() <= 2)
+}
+
 func TestSecretManager_Watch(t *testing.T) {
 	t.Parallel()
 
@@ -379,6 +488,71 @@ func (s *secretManagerService) AccessSecretVersion(
 	}, nil
 }
 
+type concurrentSecretManagerService struct {
+	secretManagerService
+
+	running    atomic.Int32
+	maxRunning atomic.Int32
+}
+
+func (s *concurrentSecretManagerService) AccessSecretVersion(
+	ctx context.Context,
+	request *pb.AccessSecretVersionRequest,
+) (*pb.AccessSecretVersionResponse, error) {
+	running := s.running.Add(1)
+	defer s.running.Add(-1)
+	for maxRunning := s.maxRunning.Load(); running > maxRunning; maxRunning = s.maxRunning.Load() {
+		if s.maxRunning.CompareAndSwap(maxRunning, running) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond) // Simulate the latency of API call.
+
+	return s.secretManagerService.AccessSecretVersion(ctx, request)
+}
+
+type versionedSecretManagerService struct {
+	pb.UnimplementedSecretManagerServiceServer
+
+	// The empty value means the version is destroyed.
+	versions map[string][]string
+}
+
+func (s *versionedSecretManagerService) ListSecrets(
+	context.Context,
+	*pb.ListSecretsRequest,
+) (*pb.ListSecretsResponse, error) {
+	resp := &pb.ListSecretsResponse{TotalSize: int32(len(s.versions))}
+	for name := range s.versions {
+		resp.Secrets = append(resp.Secrets, &pb.Secret{Name: name, Etag: name + "42"})
+	}
+
+	return resp, nil
+}
+
+func (s *versionedSecretManagerService) AccessSecretVersion(
+	_ context.Context,
+	request *pb.AccessSecretVersionRequest,
+) (*pb.AccessSecretVersionResponse, error) {
+	name, version, _ := strings.Cut(request.GetName(), "/versions/")
+	versions := s.versions[name]
+	number := len(versions)
+	if version != "latest" {
+		number, _ = strconv.Atoi(version)
+	}
+	if number < 1 || number > len(versions) {
+		return nil, status.Error(codes.NotFound, "version not found")
+	}
+	if versions[number-1] == "" {
+		return nil, status.Error(codes.FailedPrecondition, "version is destroyed")
+	}
+
+	return &pb.AccessSecretVersionResponse{
+		Name:    name + "/versions/" + strconv.Itoa(number),
+		Payload: &pb.SecretPayload{Data: []byte(versions[number-1])},
+	}, nil
+}
+
 type faultySecretManagerService struct {
 	pb.UnimplementedSecretManagerServiceServer
 
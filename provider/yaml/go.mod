@@ -0,0 +1,5 @@
+module github.com/nil-go/konf/provider/yaml
+
+go 1.22
+
+require gopkg.in/yaml.v3 v3.0.1
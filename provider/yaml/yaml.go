@@ -0,0 +1,67 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+// Package yaml provides the unmarshal function for YAML configuration,
+// which could be passed to the providers via WithUnmarshal, e.g. `file.WithUnmarshal(yaml.Unmarshal)`.
+//
+// It decodes nested YAML maps as map[string]any so that they could be merged with other loaders,
+// while gopkg.in/yaml.v3 decodes the maps with non-string keys (e.g. `1: a`) as map[any]any.
+package yaml
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Unmarshal decodes the YAML document in bytes into the value pointed to by target,
+// which must be *map[string]any. The nested maps are decoded as map[string]any,
+// and the non-string keys are formatted as string, e.g. `1` as `"1"`.
+func Unmarshal(bytes []byte, target any) error {
+	values, ok := target.(*map[string]any)
+	if !ok {
+		return errTarget
+	}
+
+	var out map[string]any
+	if err := yaml.Unmarshal(bytes, &out); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if out == nil {
+		*values = nil
+
+		return nil
+	}
+	*values, _ = normalize(out).(map[string]any)
+
+	return nil
+}
+
+var errTarget = errors.New("yaml: target must be *map[string]any")
+
+func normalize(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, val := range value {
+			value[key] = normalize(val)
+		}
+
+		return value
+	case map[any]any:
+		values := make(map[string]any, len(value))
+		for key, val := range value {
+			values[fmt.Sprint(key)] = normalize(val)
+		}
+
+		return values
+	case []any:
+		for i, val := range value {
+			value[i] = normalize(val)
+		}
+
+		return value
+	default:
+		return value
+	}
+}
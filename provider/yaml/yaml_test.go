@@ -0,0 +1,76 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package yaml_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf/provider/yaml"
+	"github.com/nil-go/konf/provider/yaml/internal/assert"
+)
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		content     string
+		expected    map[string]any
+		err         string
+	}{
+		{
+			description: "empty",
+		},
+		{
+			description: "nested maps",
+			content: `
+db:
+  host: localhost
+  ports:
+    1: 5432
+    2: 5433
+  replicas:
+    - host: replica
+      tags:
+        true: primary
+`,
+			expected: map[string]any{
+				"db": map[string]any{
+					"host":  "localhost",
+					"ports": map[string]any{"1": 5432, "2": 5433},
+					"replicas": []any{
+						map[string]any{"host": "replica", "tags": map[string]any{"true": "primary"}},
+					},
+				},
+			},
+		},
+		{
+			description: "invalid",
+			content:     `db: [`,
+			err:         "yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var values map[string]any
+			err := yaml.Unmarshal([]byte(testcase.content), &values)
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, values)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_target(t *testing.T) {
+	t.Parallel()
+
+	var values map[string]string
+	assert.EqualError(t, yaml.Unmarshal([]byte(`k: v`), &values), "yaml: target must be *map[string]any")
+}
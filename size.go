@@ -0,0 +1,52 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is the number of bytes, which can be decoded from string with SI or IEC unit suffixes,
+// e.g. "10MB" is 10,000,000 bytes and "1GiB" is 1,073,741,824 bytes.
+// The string without unit suffix is treated as the number of bytes.
+type ByteSize int64
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	index := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '_'
+	})
+	if index == -1 {
+		index = len(str)
+	}
+
+	number, unit := str[:index], strings.TrimSpace(str[index:])
+	multiplier, ok := byteSizeUnits[strings.ToLower(unit)]
+	if !ok {
+		return fmt.Errorf("invalid unit %q in byte size %q", unit, str) //nolint:err113
+	}
+	size, err := strconv.ParseFloat(strings.ReplaceAll(number, "_", ""), 64)
+	if err != nil {
+		return fmt.Errorf("invalid byte size %q: %w", str, err)
+	}
+	bytes := size * float64(multiplier)
+	if bytes >= math.MaxInt64 {
+		return fmt.Errorf("byte size %q overflows int64", str) //nolint:err113
+	}
+	*b = ByteSize(bytes)
+
+	return nil
+}
+
+//nolint:gochecknoglobals,mnd
+var byteSizeUnits = map[string]int64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "m": 1e6, "mb": 1e6, "g": 1e9, "gb": 1e9, "t": 1e12, "tb": 1e12, "p": 1e15, "pb": 1e15,
+	"e": 1e18, "eb": 1e18,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50, "eib": 1 << 60,
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"testing"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestByteSize_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		text        string
+		expected    konf.ByteSize
+		err         string
+	}{
+		{
+			description: "bytes",
+			text:        "256",
+			expected:    256,
+		},
+		{
+			description: "SI unit",
+			text:        "10MB",
+			expected:    10_000_000,
+		},
+		{
+			description: "IEC unit",
+			text:        "1GiB",
+			expected:    1 << 30,
+		},
+		{
+			description: "fraction with space",
+			text:        "1.5 kib",
+			expected:    1536,
+		},
+		{
+			description: "max exa unit",
+			text:        "9EB",
+			expected:    9_000_000_000_000_000_000,
+		},
+		{
+			description: "overflow",
+			text:        "9999999EB",
+			err:         `byte size "9999999EB" overflows int64`,
+		},
+		{
+			description: "overflow at boundary",
+			text:        "8EiB",
+			err:         `byte size "8EiB" overflows int64`,
+		},
+		{
+			description: "invalid unit",
+			text:        "10XB",
+			err:         `invalid unit "XB" in byte size "10XB"`,
+		},
+		{
+			description: "invalid number",
+			text:        "1.2.3MB",
+			err:         `invalid byte size "1.2.3MB": strconv.ParseFloat: parsing "1.2.3": invalid syntax`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			var size konf.ByteSize
+			err := size.UnmarshalText([]byte(testcase.text))
+			if testcase.err != "" {
+				assert.EqualError(t, err, testcase.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testcase.expected, size)
+			}
+		})
+	}
+}
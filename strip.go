@@ -0,0 +1,80 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// StripPrefix returns a Loader that removes the given prefix from the path of every key
+// loaded by the given loader, e.g. `app.db.host` becomes `db.host` with prefix `app`.
+// The prefix is a path delimited by `.`, and it's matched case-sensitively against the keys
+// returned by the loader. Keys not under the prefix are dropped.
+//
+// If the loader is a Watcher, the prefix is also removed from the changed values.
+// Otherwise, its Watch blocks until the context is done.
+// If the loader is a Statuser, the status is reported as it is.
+func StripPrefix(prefix string, loader Loader) Loader {
+	if loader == nil {
+		return nil
+	}
+
+	var paths []string
+	if prefix != "" {
+		paths = strings.Split(prefix, ".")
+	}
+
+	return prefixStripper{loader: loader, paths: paths}
+}
+
+type prefixStripper struct {
+	loader Loader
+	paths  []string
+}
+
+func (p prefixStripper) Load() (map[string]any, error) {
+	values, err := p.loader.Load()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return p.strip(values), nil
+}
+
+func (p prefixStripper) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	watcher, ok := p.loader.(Watcher)
+	if !ok {
+		<-ctx.Done() // Block until ctx is done as the watcher has nothing to watch.
+
+		return nil
+	}
+
+	return watcher.Watch(ctx, func(values map[string]any) { //nolint:wrapcheck
+		onChange(p.strip(values))
+	})
+}
+
+func (p prefixStripper) Status(onStatus func(changed bool, err error)) {
+	if statuser, ok := p.loader.(Statuser); ok {
+		statuser.Status(onStatus)
+	}
+}
+
+func (p prefixStripper) String() string {
+	return fmt.Sprintf("%v", p.loader)
+}
+
+func (p prefixStripper) strip(values map[string]any) map[string]any {
+	for _, path := range p.paths {
+		sub, ok := values[path].(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		values = sub
+	}
+
+	return values
+}
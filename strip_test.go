@@ -0,0 +1,130 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nil-go/konf"
+	"github.com/nil-go/konf/internal/assert"
+)
+
+func TestStripPrefix(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		description string
+		prefix      string
+		values      map[string]any
+		expected    map[string]any
+	}{
+		{
+			description: "single level",
+			prefix:      "app",
+			values:      map[string]any{"app": map[string]any{"key": "value"}, "other": "value"},
+			expected:    map[string]any{"key": "value"},
+		},
+		{
+			description: "multiple levels",
+			prefix:      "app.db",
+			values:      map[string]any{"app": map[string]any{"db": map[string]any{"host": "localhost"}}},
+			expected:    map[string]any{"host": "localhost"},
+		},
+		{
+			description: "not found",
+			prefix:      "app",
+			values:      map[string]any{"other": "value"},
+			expected:    map[string]any{},
+		},
+		{
+			description: "empty prefix",
+			prefix:      "",
+			values:      map[string]any{"key": "value"},
+			expected:    map[string]any{"key": "value"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.description, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := konf.StripPrefix(testcase.prefix, mapLoader(testcase.values)).Load()
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expected, values)
+		})
+	}
+}
+
+func TestStripPrefix_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := konf.StripPrefix("app", errorLoader{}).Load()
+	assert.EqualError(t, err, "load error")
+}
+
+func TestStripPrefix_watch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := nestedWatcher{value: make(chan string)}
+	assert.NoError(t, config.Load(konf.StripPrefix("app", watcher)))
+
+	var value string
+	assert.NoError(t, config.Unmarshal("key", &value))
+	assert.Equal(t, "value", value)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	newValue := make(chan string)
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("key", &value))
+		newValue <- value
+	}, "key")
+	watcher.value <- "changed"
+	assert.Equal(t, "changed", <-newValue)
+}
+
+func TestStripPrefix_Watch_nonWatcher(t *testing.T) {
+	t.Parallel()
+
+	loader, ok := konf.StripPrefix("app", mapLoader{}).(konf.Watcher)
+	assert.True(t, ok)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, loader.Watch(ctx, func(map[string]any) { t.Error("unexpected change") }))
+	assert.True(t, ctx.Err() != nil)
+}
+
+type nestedWatcher struct {
+	value chan string
+}
+
+func (nestedWatcher) Load() (map[string]any, error) {
+	return map[string]any{"app": map[string]any{"key": "value"}}, nil
+}
+
+func (n nestedWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case value := <-n.value:
+			onChange(map[string]any{"app": map[string]any{"key": value}})
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2024 The konf authors
+// Use of this source code is governed by a MIT license found in the LICENSE file.
+
+package konf
+
+import (
+	"time"
+)
+
+// timeHook returns the decode hook which parses string to time.Time,
+// trying time.RFC3339 first and then the given layouts in order.
+// It returns the error of parsing with time.RFC3339 if none of the layouts matches.
+func timeHook(layouts []string) func(string) (time.Time, error) {
+	return func(from string) (time.Time, error) {
+		value, err := time.Parse(time.RFC3339, from)
+		if err == nil {
+			return value, nil
+		}
+		for _, layout := range layouts {
+			if value, lerr := time.Parse(layout, from); lerr == nil {
+				return value, nil
+			}
+		}
+
+		return time.Time{}, err
+	}
+}
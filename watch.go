@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,9 +20,16 @@ import (
 // Watch watches and updates configuration when it changes.
 // It blocks until ctx is done, or the service returns an error.
 //
+// The context passed to each Watcher derives from ctx, so the values attached to ctx
+// (e.g. authentication tokens) are visible in Watcher.Watch.
+//
 // It only can be called once. Call after first has no effects.
 func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocognit
 	c.nocopy.Check()
+	c.checkInit()
+	if c.parent != nil {
+		return c.parent.Watch(ctx)
+	}
 
 	ctx, cancel := context.WithCancelCause(ctx)
 	defer cancel(nil)
@@ -38,8 +47,20 @@ func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocog
 				defer waitGroup.Done()
 
 				onChange := func(values map[string]any) {
-					c.transformKeys(values)
+					start := time.Now()
+					values, err := c.transform(values)
+					if err != nil {
+						c.log(ctx, slog.LevelWarn,
+							"Error when transforming configuration, the change is ignored.",
+							slog.Any("loader", watcher),
+							slog.Any("error", err),
+						)
+
+						return
+					}
 					oldValues := *provider.values.Swap(&values)
+					provider.duration.Store(int64(time.Since(start)))
+					c.recorder().Changed(fmt.Sprintf("%v", watcher))
 					onChangesChannel <- c.onChanges.get(
 						func(path string) bool {
 							paths := c.splitPath(path)
@@ -54,9 +75,31 @@ func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocog
 					)
 				}
 
-				c.log(ctx, slog.LevelDebug, "Watching configuration change.", slog.Any("loader", watcher))
-				if err := watcher.Watch(ctx, onChange); err != nil {
-					cancel(fmt.Errorf("watch configuration change on %v: %w", watcher, err))
+				for {
+					c.log(ctx, slog.LevelDebug, "Watching configuration change.", slog.Any("loader", watcher))
+					if err := watcher.Watch(ctx, onChange); err != nil {
+						cancel(fmt.Errorf("watch configuration change on %v: %w", watcher, err))
+
+						return
+					}
+					if c.restartBackoff <= 0 || ctx.Err() != nil {
+						return
+					}
+
+					// Restart the watcher after backoff since it stops before ctx is done.
+					c.log(ctx, slog.LevelWarn,
+						"Watcher stopped unexpectedly, restart it after backoff.",
+						slog.Any("loader", watcher),
+						slog.Duration("backoff", c.restartBackoff),
+					)
+					timer := time.NewTimer(c.restartBackoff)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+
+						return
+					case <-timer.C:
+					}
 				}
 			}(ctx)
 		}
@@ -80,9 +123,21 @@ func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocog
 				return
 
 			case onChanges := <-onChangesChannel:
+				oldValues := c.providers.peek(nil)
 				c.providers.changed()
 				c.log(ctx, slog.LevelDebug, "Configuration has been updated with change.")
 
+				if batches := c.onChanges.getBatches(); len(batches) > 0 {
+					oldMap, _ := oldValues.(map[string]any)
+					newMap, _ := c.providers.peek(nil).(map[string]any)
+					added, removed, changed := Diff(oldMap, newMap, c.delim())
+					if paths := slices.Concat(added, removed, changed); len(paths) > 0 {
+						for _, batch := range batches {
+							batch.add(c, paths)
+						}
+					}
+				}
+
 				if len(onChanges) > 0 {
 					func() {
 						done := make(chan struct{})
@@ -129,6 +184,7 @@ func (c *Config) Watch(ctx context.Context) error { //nolint:cyclop,funlen,gocog
 // when the value of any given path in the Config changes.
 // It requires Config.Watch has been called first.
 // The paths are case-insensitive unless konf.WithCaseSensitive is set.
+// The callback is executed once per change even if the values of several given paths change.
 //
 // The register function must be non-blocking and usually completes instantly.
 // If it requires a long time to complete, it should be executed in a separate goroutine.
@@ -138,6 +194,22 @@ func (c *Config) OnChange(onChange func(*Config), paths ...string) {
 	if onChange == nil {
 		return // Do nothing is onchange is nil.
 	}
+
+	c.OnChangePaths(func(config *Config, _ []string) { onChange(config) }, paths...)
+}
+
+// OnChangePaths works like Config.OnChange, but the callback function also receives
+// the given paths whose values have changed, sorted and in lower case unless konf.WithCaseSensitive is set.
+// If no path is given, the changed paths is empty.
+//
+// The register function must be non-blocking and usually completes instantly.
+// If it requires a long time to complete, it should be executed in a separate goroutine.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangePaths(onChange func(config *Config, changedPaths []string), paths ...string) {
+	if onChange == nil {
+		return // Do nothing is onchange is nil.
+	}
 	c.nocopy.Check()
 
 	if !c.caseSensitive {
@@ -145,15 +217,169 @@ func (c *Config) OnChange(onChange func(*Config), paths ...string) {
 			paths[i] = defaultKeyMap(paths[i])
 		}
 	}
+	if c.parent != nil {
+		// Register the paths under the prefix on the parent Config for the sub Config.
+		prefix := strings.Join(c.prefix, c.delim())
+		all := len(paths) == 0
+		if all {
+			paths = []string{prefix}
+		} else if prefix != "" {
+			for i := range paths {
+				paths[i] = prefix + c.delim() + paths[i]
+			}
+		}
+		c.parent.OnChangePaths(func(_ *Config, changedPaths []string) {
+			if all {
+				changedPaths = nil
+			} else if prefix != "" {
+				for i := range changedPaths {
+					changedPaths[i] = strings.TrimPrefix(changedPaths[i], prefix+c.delim())
+				}
+			}
+			onChange(c, changedPaths)
+		}, paths...)
+
+		return
+	}
 	c.onChanges.register(onChange, paths)
 }
 
+// Bind reads configuration under the given path from the Config
+// and decodes it into the given object pointed to by target immediately,
+// and then keeps target updated when the value of the path changes.
+// It requires Config.Watch has been called for updating target.
+// The path is case-insensitive unless konf.WithCaseSensitive is set.
+//
+// If target implements sync.Locker (e.g. a struct embedding sync.Mutex),
+// it's locked while updating, so the readers holding the lock see consistent values.
+// The error while updating is logged rather than returned.
+//
+// This method is concurrent-safe.
+func (c *Config) Bind(path string, target any) error {
+	if c == nil { // To support nil
+		return nil
+	}
+
+	unmarshal := func() error {
+		if locker, ok := target.(sync.Locker); ok {
+			locker.Lock()
+			defer locker.Unlock()
+		}
+
+		return c.Unmarshal(path, target)
+	}
+	if err := unmarshal(); err != nil {
+		return err
+	}
+
+	c.OnChange(func(*Config) {
+		if err := unmarshal(); err != nil {
+			c.log(context.Background(), slog.LevelWarn,
+				"Could not update bound target with changed configuration.",
+				slog.String("path", path),
+				slog.Any("error", err),
+			)
+		}
+	}, path)
+
+	return nil
+}
+
+// OnChangeBatch registers a callback function that is executed once with all the changed paths
+// when the Config changes. The changes within the given window since the first change
+// are collected into a single call, which avoids redundant rebuilds for many related paths.
+// The changed paths are the leaf paths joined by the delimiter, and sorted.
+// It requires Config.Watch has been called first.
+//
+// The register function must be non-blocking and usually completes instantly.
+// If it requires a long time to complete, it should be executed in a separate goroutine.
+//
+// This method is concurrent-safe.
+func (c *Config) OnChangeBatch(onChange func(config *Config, changedPaths []string), window time.Duration) {
+	if onChange == nil {
+		return // Do nothing is onchange is nil.
+	}
+	c.nocopy.Check()
+
+	if c.parent != nil {
+		// Register on the parent Config with the changed paths under the prefix for the sub Config.
+		prefix := strings.Join(c.prefix, c.delim()) + c.delim()
+		c.parent.OnChangeBatch(func(_ *Config, changedPaths []string) {
+			var paths []string
+			for _, path := range changedPaths {
+				if path, ok := strings.CutPrefix(path, prefix); ok || len(c.prefix) == 0 {
+					paths = append(paths, path)
+				}
+			}
+			if len(paths) > 0 {
+				onChange(c, paths)
+			}
+		}, window)
+
+		return
+	}
+	c.onChanges.registerBatch(&changeBatch{onChange: onChange, window: window})
+}
+
+type changeBatch struct {
+	onChange func(*Config, []string)
+	window   time.Duration
+	paths    map[string]struct{}
+	mutex    sync.Mutex
+}
+
+func (b *changeBatch) add(config *Config, paths []string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.paths == nil {
+		// Start a new window with the first change.
+		b.paths = make(map[string]struct{})
+		time.AfterFunc(b.window, func() { b.flush(config) })
+	}
+	for _, path := range paths {
+		b.paths[path] = struct{}{}
+	}
+}
+
+func (b *changeBatch) flush(config *Config) {
+	b.mutex.Lock()
+	paths := make([]string, 0, len(b.paths))
+	for path := range b.paths {
+		paths = append(paths, path)
+	}
+	b.paths = nil
+	b.mutex.Unlock()
+
+	slices.Sort(paths)
+	b.onChange(config, paths)
+}
+
 type onChanges struct {
-	subscribers map[string][]func(*Config)
+	subscribers map[string][]*onChangeSubscriber
+	batches     []*changeBatch
 	mutex       sync.RWMutex
 }
 
-func (o *onChanges) register(onChange func(*Config), paths []string) {
+type onChangeSubscriber struct {
+	onChange func(*Config, []string)
+}
+
+func (o *onChanges) registerBatch(batch *changeBatch) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.batches = append(o.batches, batch)
+}
+
+func (o *onChanges) getBatches() []*changeBatch {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	return o.batches
+}
+
+func (o *onChanges) register(onChange func(*Config, []string), paths []string) {
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 
@@ -162,22 +388,44 @@ func (o *onChanges) register(onChange func(*Config), paths []string) {
 	}
 
 	if o.subscribers == nil {
-		o.subscribers = make(map[string][]func(*Config))
+		o.subscribers = make(map[string][]*onChangeSubscriber)
 	}
+	subscriber := &onChangeSubscriber{onChange: onChange}
 	for _, path := range paths {
-		o.subscribers[path] = append(o.subscribers[path], onChange)
+		o.subscribers[path] = append(o.subscribers[path], subscriber)
 	}
 }
 
+// get returns the callbacks of subscribers which have any path matching the filter.
+// Each callback is executed once with all matched paths of the subscriber.
 func (o *onChanges) get(filter func(string) bool) []func(*Config) {
 	o.mutex.RLock()
 	defer o.mutex.RUnlock()
 
-	var callbacks []func(*Config)
-	for path, subscriber := range o.subscribers {
-		if filter(path) {
-			callbacks = append(callbacks, subscriber...)
+	var (
+		subscribers []*onChangeSubscriber
+		paths       = make(map[*onChangeSubscriber][]string)
+	)
+	for path, pathSubscribers := range o.subscribers {
+		if !filter(path) {
+			continue
 		}
+		for _, subscriber := range pathSubscribers {
+			if _, ok := paths[subscriber]; !ok {
+				subscribers = append(subscribers, subscriber)
+				paths[subscriber] = nil
+			}
+			if path != "" {
+				paths[subscriber] = append(paths[subscriber], path)
+			}
+		}
+	}
+
+	callbacks := make([]func(*Config), 0, len(subscribers))
+	for _, subscriber := range subscribers {
+		changedPaths := paths[subscriber]
+		slices.Sort(changedPaths)
+		callbacks = append(callbacks, func(config *Config) { subscriber.onChange(config, changedPaths) })
 	}
 
 	return callbacks
@@ -27,7 +27,7 @@ func TestConfig_Watch(t *testing.T) {
 	t.Parallel()
 
 	var config konf.Config
-	watcher := stringWatcher{key: "Config", value: make(chan string)}
+	watcher := stringWatcher{key: "Config", value: make(chan string), delay: 10 * time.Millisecond}
 	err := config.Load(watcher)
 	assert.NoError(t, err)
 
@@ -53,8 +53,11 @@ func TestConfig_Watch(t *testing.T) {
 		assert.NoError(t, config.Unmarshal("config", &value))
 		newValue <- value
 	}, "config")
+	assert.True(t, config.LoadTimings()["stringWatcher"] >= 10*time.Millisecond)
 	watcher.change()
 	assert.Equal(t, "changed", <-newValue)
+	// The load timing is replaced by the duration of reloading the change, which does not call Load.
+	assert.True(t, config.LoadTimings()["stringWatcher"] < 10*time.Millisecond)
 }
 
 func TestConfig_Watch_race(t *testing.T) {
@@ -212,6 +215,72 @@ func TestConfig_Watch_status(t *testing.T) {
 	assert.EqualError(t, *err.Load(), "watch error")
 }
 
+func TestConfig_Watch_metrics(t *testing.T) {
+	t.Parallel()
+
+	recorder := &metricsRecorder{}
+	config := konf.New(
+		konf.WithLogHandler(logHandler(&buffer{})),
+		konf.WithMetrics(recorder),
+	)
+	watcher := stringWatcher{key: "Config", value: make(chan string)}
+	assert.NoError(t, config.Load(watcher))
+	assert.NoError(t, config.Load(&statusWatcher{}))
+	assert.EqualError(t, config.Load(errorLoader{}), "load configuration: load error")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	time.Sleep(100 * time.Millisecond) // Wait for watch to start
+
+	changed := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(changed) }, "config")
+	watcher.change()
+	<-changed
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	assert.Equal(t, []string{"stringWatcher", "status"}, recorder.loads)
+	assert.Equal(t, []string{"{}", "status"}, recorder.errors)
+	assert.Equal(t, []string{"stringWatcher"}, recorder.changes)
+}
+
+type metricsRecorder struct {
+	loads   []string
+	errors  []string
+	changes []string
+	mutex   sync.Mutex
+}
+
+func (m *metricsRecorder) LoadDuration(loader string, _ time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.loads = append(m.loads, loader)
+}
+
+func (m *metricsRecorder) LoadError(loader string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.errors = append(m.errors, loader)
+}
+
+func (m *metricsRecorder) Changed(loader string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.changes = append(m.changes, loader)
+}
+
 func TestConfig_Watch_panic(t *testing.T) {
 	t.Parallel()
 
@@ -245,12 +314,274 @@ func TestConfig_Watch_panic(t *testing.T) {
 	}
 }
 
+func TestConfig_Watch_context_value(t *testing.T) {
+	t.Parallel()
+
+	type contextKey struct{}
+	values := make(chan any, 1)
+	var config konf.Config
+	assert.NoError(t, config.Load(contextWatcher{key: contextKey{}, values: values}))
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "token"))
+	defer cancel()
+	go func() {
+		assert.NoError(t, config.Watch(ctx))
+	}()
+	assert.Equal(t, "token", <-values)
+}
+
+func TestConfig_Bind(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := nestedWatcher{value: make(chan string)}
+	assert.NoError(t, config.Load(watcher))
+
+	var target struct {
+		sync.Mutex
+		Key string
+	}
+	assert.NoError(t, config.Bind("App", &target))
+	assert.Equal(t, "value", target.Key)
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	// The callbacks are executed in order, so target has been updated when it's called.
+	updated := make(chan struct{})
+	config.OnChange(func(*konf.Config) { close(updated) }, "app")
+	watcher.value <- "changed"
+	<-updated
+
+	target.Lock()
+	defer target.Unlock()
+	assert.Equal(t, "changed", target.Key)
+}
+
+func TestConfig_Bind_error(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	assert.NoError(t, config.Load(mapLoader{"config": "string"}))
+
+	var target bool
+	assert.EqualError(t, config.Bind("config", &target),
+		"decode: cannot parse '' as bool: strconv.ParseBool: parsing \"string\": invalid syntax")
+}
+
+func TestConfig_Watch_auto_restart(t *testing.T) {
+	t.Parallel()
+
+	buf := &buffer{}
+	config := konf.New(
+		konf.WithLogHandler(logHandler(buf)),
+		konf.WithWatcherAutoRestart(10*time.Millisecond),
+	)
+	watcher := &flakyWatcher{stringWatcher: stringWatcher{key: "Config", value: make(chan string)}}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	newValue := make(chan string)
+	config.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("config", &value))
+		newValue <- value
+	}, "config")
+	watcher.change()
+	assert.Equal(t, "changed", <-newValue)
+	assert.Equal(t, int32(2), watcher.watched.Load())
+	time.Sleep(10 * time.Millisecond) // Wait for log to be written
+	expected := `level=WARN msg="Watcher stopped unexpectedly, restart it after backoff." loader=stringWatcher backoff=10ms
+level=INFO msg="Configuration has been changed." loader=stringWatcher
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestConfig_OnChangeBatch(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	batches := make(chan []string, 2)
+	config.OnChangeBatch(func(_ *konf.Config, paths []string) {
+		batches <- paths
+	}, 100*time.Millisecond)
+	watcher.values <- map[string]any{"a": "1"}
+	watcher.values <- map[string]any{"a": "2", "b": "1"}
+	watcher.values <- map[string]any{"a": "2", "b": "1", "c": map[string]any{"d": "1"}}
+	assert.Equal(t, []string{"a", "b", "c.d"}, <-batches)
+
+	select {
+	case paths := <-batches:
+		t.Errorf("unexpected batch: %v", paths)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestConfig_Sub_OnChange(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+	sub := config.Sub("app")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, sub.Watch(ctx))
+	}()
+
+	newValue := make(chan string)
+	sub.OnChange(func(config *konf.Config) {
+		var value string
+		assert.NoError(t, config.Unmarshal("key", &value))
+		newValue <- value
+	})
+	watcher.values <- map[string]any{"other": "changed"}
+	watcher.values <- map[string]any{"other": "changed", "app": map[string]any{"key": "changed"}}
+	assert.Equal(t, "changed", <-newValue)
+}
+
+func TestConfig_OnChangePaths(t *testing.T) {
+	t.Parallel()
+
+	config := konf.New()
+	watcher := mapWatcher{values: make(chan map[string]any)}
+	assert.NoError(t, config.Load(watcher))
+	sub := config.Sub("App")
+
+	stopped := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+	go func() {
+		defer close(stopped)
+
+		assert.NoError(t, config.Watch(ctx))
+	}()
+
+	changedPaths := make(chan []string, 3)
+	config.OnChangePaths(func(_ *konf.Config, paths []string) {
+		changedPaths <- paths
+	}, "A", "b", "c")
+	subChangedPaths := make(chan []string, 3)
+	sub.OnChangePaths(func(_ *konf.Config, paths []string) {
+		subChangedPaths <- paths
+	}, "Key", "other")
+	changes := make(chan struct{}, 3)
+	config.OnChange(func(*konf.Config) {
+		changes <- struct{}{}
+	}, "a", "b")
+	watcher.values <- map[string]any{"a": "1", "b": "1", "app": map[string]any{"key": "1"}}
+	assert.Equal(t, []string{"a", "b"}, <-changedPaths)
+	assert.Equal(t, []string{"key"}, <-subChangedPaths)
+	// The callbacks of the previous change have completed once the next change is received.
+	watcher.values <- map[string]any{"a": "1", "b": "1", "c": "1", "app": map[string]any{"key": "1"}}
+	assert.Equal(t, []string{"c"}, <-changedPaths)
+	assert.Equal(t, 1, len(changes)) // OnChange is executed once even if both paths change.
+}
+
+type mapWatcher struct {
+	values chan map[string]any
+}
+
+func (mapWatcher) Load() (map[string]any, error) {
+	return map[string]any{}, nil
+}
+
+func (m mapWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	for {
+		select {
+		case values := <-m.values:
+			onChange(values)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// flakyWatcher returns from the first Watch immediately.
+type flakyWatcher struct {
+	stringWatcher
+	watched atomic.Int32
+}
+
+func (f *flakyWatcher) Watch(ctx context.Context, onChange func(map[string]any)) error {
+	if f.watched.Add(1) == 1 {
+		return nil
+	}
+
+	return f.stringWatcher.Watch(ctx, onChange)
+}
+
+type contextWatcher struct {
+	key    any
+	values chan any
+}
+
+func (contextWatcher) Load() (map[string]any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (c contextWatcher) Watch(ctx context.Context, _ func(map[string]any)) error {
+	c.values <- ctx.Value(c.key)
+	<-ctx.Done()
+
+	return nil
+}
+
 type stringWatcher struct {
 	key   string
 	value chan string
+	delay time.Duration
 }
 
 func (m stringWatcher) Load() (map[string]any, error) {
+	time.Sleep(m.delay)
+
 	return map[string]any{m.key: ""}, nil
 }
 